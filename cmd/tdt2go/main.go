@@ -33,19 +33,40 @@ func main() {
 }
 
 var generatedFile string
+var outputDir string
+var splitByNamespace bool
+var removeStaleFiles bool
 var packageName string
 var includePatterns []string
 var excludePatterns []string
 var nameMappings map[string]string
 var generateBuiltinTypes bool
+var importPaths []string
+var generateImportedTypes bool
+var generateValidation bool
+var generateEnums bool
+var optionalPointers bool
+var generateDefaults bool
+var lenientTimestamps bool
+var generateNodeTypes bool
+var generateCapabilityTypes bool
+var generateRelationshipTypes bool
+var generateOperationInputs bool
+var unresolvedTypesFallback string
+var disambiguateNames bool
+var suffixCollidingFields bool
+var flattenStructs bool
+var generateParentMethods bool
 
 func init() {
 
 	rootCmd = &cobra.Command{
-		Args:  cobra.ExactArgs(1),
-		Use:   "tdt2go <tosca_file>",
+		Args:  cobra.MinimumNArgs(1),
+		Use:   "tdt2go <tosca_file_or_csar>...",
 		Short: "Generate Go structures from TOSCA datatypes",
-		Long:  `tdt2go allows to generate Go source files containing data structures generated from files containing TOSCA data types`,
+		Long: `tdt2go allows to generate Go source files containing data structures generated from files or CSAR archives containing TOSCA data types.
+
+Several files or glob patterns (like 'tosca/*.yaml') could be given, their data types are merged and generated into a single Go source file.`,
 		// Uncomment the following line if your bare application
 		// has an action associated with it:
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -53,20 +74,51 @@ func init() {
 			if err != nil {
 				return err
 			}
-			return tdt2go.GenerateFile(args[0], opts...)
+			return tdt2go.GenerateFiles(args, opts...)
 		},
 	}
 
 	rootCmd.Flags().StringVarP(&generatedFile, "file", "f", "", "file to be generated, if not defined resulting generated file will be printed on default output.")
+	rootCmd.Flags().StringVarP(&outputDir, "output-dir", "o", "", "directory where generated code is written as one file per type (and a shared builtin.go file for builtin types) instead of a single file. Existing files are only overwritten if they were generated by tdt2go.")
+	rootCmd.Flags().BoolVar(&splitByNamespace, "split-by-namespace", false, "Group types generated into the output directory into one file per TOSCA namespace (like org_ystia_datatypes.go) instead of one file per type. (default: false)")
+	rootCmd.Flags().BoolVar(&removeStaleFiles, "remove-stale-files", false, "Remove files previously generated by tdt2go into the output directory that are not generated anymore. (default: false)")
 	rootCmd.Flags().StringVarP(&packageName, "package", "p", "", "package name as it should appear in source file, defaults to the package name of the current directory.")
 	rootCmd.Flags().StringSliceVarP(&includePatterns, "include", "i", nil, "regexp patterns of data types fully qualified names to include. Only matching datatypes will be transformed. Include patterns have the precedence over exclude patterns.")
 	rootCmd.Flags().StringSliceVarP(&excludePatterns, "exclude", "e", nil, "regexp patterns of data types fully qualified names to exclude. Only non-matching datatypes will be transformed. Include patterns have the precedence over exclude patterns.")
 	rootCmd.Flags().BoolVarP(&generateBuiltinTypes, "generate-builtin", "b", false, "Generate tosca builtin types as 'range' or 'scalar-unit' for instance along with datatypes in this file. (default: false)")
+	rootCmd.Flags().StringSliceVarP(&importPaths, "import-path", "I", nil, "directories where TOSCA imports are searched for when they can't be found relatively to the importing file.")
+	rootCmd.Flags().BoolVar(&generateImportedTypes, "generate-imported", false, "Generate datatypes defined in imported TOSCA files along with datatypes in this file. (default: false)")
+	rootCmd.Flags().BoolVar(&generateValidation, "generate-validation", false, "Generate on each datatype a Validate method enforcing TOSCA constraints. (default: false)")
+	rootCmd.Flags().BoolVar(&generateEnums, "generate-enums", false, "Generate string properties restricted by a valid_values constraint as a dedicated string type with a constant for each valid value. (default: false)")
+	rootCmd.Flags().BoolVar(&optionalPointers, "optional-pointers", false, "Generate optional properties of scalar types as pointers so absent values could be distinguished from zero values. (default: false)")
+	rootCmd.Flags().BoolVar(&generateDefaults, "generate-defaults", false, "Generate on each complex datatype a constructor and a SetDefaults method applying TOSCA default values. (default: false)")
+	rootCmd.Flags().BoolVar(&lenientTimestamps, "lenient-timestamps", false, "Generate TOSCA timestamps using a Timestamp type accepting every YAML 1.1 timestamp forms instead of time.Time which only accepts RFC 3339 timestamps. (default: false)")
+	rootCmd.Flags().BoolVar(&generateNodeTypes, "generate-node-types", false, "Generate properties and attributes of node types as <Type>Properties and <Type>Attributes structs along with datatypes. (default: false)")
+	rootCmd.Flags().BoolVar(&generateCapabilityTypes, "generate-capability-types", false, "Generate properties of capability types as <Type>CapabilityProperties structs along with datatypes. (default: false)")
+	rootCmd.Flags().BoolVar(&generateRelationshipTypes, "generate-relationship-types", false, "Generate properties of relationship types as <Type>RelationshipProperties structs along with datatypes. (default: false)")
+	rootCmd.Flags().BoolVar(&generateOperationInputs, "generate-operation-inputs", false, "Generate inputs of operations of interface types as <Interface><Operation>Inputs structs and of operations defined in node types interfaces as <Type><Interface><Operation>Inputs structs along with datatypes. (default: false)")
+	rootCmd.Flags().BoolVar(&disambiguateNames, "disambiguate-names", false, "Prefix Go names of TOSCA types colliding with other types names (like org.a.Config and org.b.Config) with their distinguishing namespace segments (like AConfig and BConfig). Otherwise generation fails on names collisions. (default: false)")
+	rootCmd.Flags().BoolVar(&suffixCollidingFields, "suffix-colliding-fields", false, "Suffix by a number Go fields names colliding with other fields names of a same type (like my-field and my_field) or with inherited fields names. Otherwise generation fails on fields names collisions. (default: false)")
+	rootCmd.Flags().BoolVar(&flattenStructs, "flatten-structs", false, "Copy inherited properties into structs generated for derived types instead of embedding the parent struct. (default: false)")
+	rootCmd.Flags().BoolVar(&generateParentMethods, "generate-parent-methods", false, "Generate on flattened structs a Parent method converting values into their parent type. (default: false)")
+	rootCmd.Flags().StringVar(&unresolvedTypesFallback, "unresolved-types-fallback", "", "Go type (like interface{} or map[string]interface{}) used for properties referencing TOSCA types that are neither defined in the TOSCA file nor in its imports. Types deriving from such unknown types are generated without parent. By default generation fails on such unresolved types.")
 	rootCmd.Flags().StringToStringVarP(&nameMappings, "name-mappings", "m", nil, "map of regular expressions and their corresponding remplacements that will be applied to TOSCA datatypes fully qualified names to transform them into Go struct names. This is generally used to keep information from the fully qualified name into the generated name.")
 }
 
 func generateOptions() ([]tdt2go.Option, error) {
 	opts := make([]tdt2go.Option, 0)
+	if generatedFile != "" && outputDir != "" {
+		return nil, fmt.Errorf("--file and --output-dir flags are mutually exclusive")
+	}
+	if outputDir != "" {
+		opts = append(opts, tdt2go.OutputDirectory(outputDir))
+	}
+	if splitByNamespace {
+		opts = append(opts, tdt2go.SplitByNamespace(true))
+	}
+	if removeStaleFiles {
+		opts = append(opts, tdt2go.RemoveStaleFiles(true))
+	}
 	if generatedFile != "" {
 		o, err := tdt2go.OutputToFile(generatedFile, 0664)
 		if err != nil {
@@ -86,6 +138,54 @@ func generateOptions() ([]tdt2go.Option, error) {
 	if generateBuiltinTypes {
 		opts = append(opts, tdt2go.GenerateBuiltinTypes(true))
 	}
+	if importPaths != nil {
+		opts = append(opts, tdt2go.ImportPaths(importPaths))
+	}
+	if generateImportedTypes {
+		opts = append(opts, tdt2go.GenerateImportedTypes(true))
+	}
+	if generateValidation {
+		opts = append(opts, tdt2go.GenerateValidation(true))
+	}
+	if generateEnums {
+		opts = append(opts, tdt2go.GenerateEnums(true))
+	}
+	if optionalPointers {
+		opts = append(opts, tdt2go.OptionalPointers(true))
+	}
+	if generateDefaults {
+		opts = append(opts, tdt2go.GenerateDefaults(true))
+	}
+	if lenientTimestamps {
+		opts = append(opts, tdt2go.LenientTimestamps(true))
+	}
+	if generateNodeTypes {
+		opts = append(opts, tdt2go.GenerateNodeTypes(true))
+	}
+	if generateCapabilityTypes {
+		opts = append(opts, tdt2go.GenerateCapabilityTypes(true))
+	}
+	if generateRelationshipTypes {
+		opts = append(opts, tdt2go.GenerateRelationshipTypes(true))
+	}
+	if generateOperationInputs {
+		opts = append(opts, tdt2go.GenerateOperationInputs(true))
+	}
+	if disambiguateNames {
+		opts = append(opts, tdt2go.DisambiguateNames(true))
+	}
+	if suffixCollidingFields {
+		opts = append(opts, tdt2go.SuffixCollidingFields(true))
+	}
+	if flattenStructs {
+		opts = append(opts, tdt2go.FlattenStructs(true))
+	}
+	if generateParentMethods {
+		opts = append(opts, tdt2go.GenerateParentMethods(true))
+	}
+	if unresolvedTypesFallback != "" {
+		opts = append(opts, tdt2go.UnresolvedTypesFallback(unresolvedTypesFallback))
+	}
 	if nameMappings != nil {
 		opts = append(opts, tdt2go.NameMappings(nameMappings))
 	}
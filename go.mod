@@ -1,6 +1,6 @@
 module github.com/ystia/tdt2go
 
-go 1.13
+go 1.16
 
 require (
 	github.com/golang/protobuf v1.3.2 // indirect
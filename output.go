@@ -0,0 +1,155 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tdt2go
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/serenize/snaker"
+
+	"github.com/ystia/tdt2go/pkg/generator"
+	"github.com/ystia/tdt2go/pkg/model"
+)
+
+// builtinFileName is the name of the file builtin types are generated into when using OutputDirectory
+const builtinFileName = "builtin.go"
+
+// knownOS and knownArch are GOOS and GOARCH values that would add implicit build constraints to a file
+// name ending by _GOOS or _GOARCH
+var knownOS = []string{"aix", "android", "darwin", "dragonfly", "freebsd", "hurd", "illumos", "ios", "js", "linux",
+	"nacl", "netbsd", "openbsd", "plan9", "solaris", "windows", "zos"}
+var knownArch = []string{"386", "amd64", "amd64p32", "arm", "armbe", "arm64", "arm64be", "ppc64", "ppc64le",
+	"mips", "mipsle", "mips64", "mips64le", "mips64p32", "mips64p32le", "ppc", "riscv", "riscv64", "s390",
+	"s390x", "sparc", "sparc64", "wasm"}
+
+// outputDirectory writes the Go source files generated for f into the output directory
+func outputDirectory(f model.File, options *Options) error {
+	fileName := typeFileName
+	if options.splitByNamespace {
+		fileName = namespaceFileName
+	}
+	files, err := newGenerator(options).GenerateFiles(f, fileName)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(options.outputDir, 0775)
+	if err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	for name, content := range files {
+		filePath := filepath.Join(options.outputDir, name)
+		generated, err := isGeneratedFile(filePath)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to write generated file: %w", err)
+		}
+		if err == nil && !generated {
+			return fmt.Errorf("failed to write generated file: %q already exists and was not generated by tdt2go", filePath)
+		}
+		err = os.WriteFile(filePath, content, 0664)
+		if err != nil {
+			return fmt.Errorf("failed to write generated file: %w", err)
+		}
+	}
+	if options.removeStaleFiles {
+		return removeStaleFiles(options.outputDir, files)
+	}
+	return nil
+}
+
+// removeStaleFiles removes Go source files generated by tdt2go into dir that are not part of files
+func removeStaleFiles(dir string, files map[string][]byte) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to remove stale generated files: %w", err)
+	}
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".go" || files[e.Name()] != nil {
+			continue
+		}
+		filePath := filepath.Join(dir, e.Name())
+		generated, err := isGeneratedFile(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to remove stale generated files: %w", err)
+		}
+		if !generated {
+			continue
+		}
+		err = os.Remove(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to remove stale generated files: %w", err)
+		}
+	}
+	return nil
+}
+
+func isGeneratedFile(filePath string) (bool, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return false, err
+	}
+	return generator.IsGeneratedFile(content), nil
+}
+
+// typeFileName returns the name of the file a type is generated into when types are generated
+// into one file per type, like port_spec.go for PortSpec
+func typeFileName(dt model.DataType) string {
+	if isBuiltinType(dt) {
+		return builtinFileName
+	}
+	return goFileName(snaker.CamelToSnake(dt.Name))
+}
+
+// namespaceFileName returns the name of the file a type is generated into when types are grouped by
+// TOSCA namespace, like org_ystia_datatypes.go for org.ystia.datatypes.PortSpec
+func namespaceFileName(dt model.DataType) string {
+	if isBuiltinType(dt) {
+		return builtinFileName
+	}
+	i := strings.LastIndex(dt.FQDTN, ".")
+	if i <= 0 {
+		return goFileName("types")
+	}
+	return goFileName(dt.FQDTN[:i])
+}
+
+func isBuiltinType(dt model.DataType) bool {
+	return dt.Kind == model.DataTypeKind && strings.HasPrefix(dt.FQDTN, "tosca:")
+}
+
+// goFileName converts name into a Go source file name. Names that would be considered as test files or that
+// would add implicit build constraints (like config_windows) are suffixed by _types.
+func goFileName(name string) string {
+	b := &bytes.Buffer{}
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	name = strings.Trim(b.String(), "_")
+	elems := strings.Split(name, "_")
+	last := elems[len(elems)-1]
+	if len(elems) > 1 && (last == "test" || strSliceContains(knownOS, last) || strSliceContains(knownArch, last)) {
+		name += "_types"
+	}
+	return name + ".go"
+}
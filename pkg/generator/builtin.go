@@ -0,0 +1,184 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"embed"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/ystia/tdt2go/pkg/model"
+)
+
+// builtinFS contains sources of methods generated along with TOSCA builtin types,
+// see the builtin package for details.
+//
+//go:embed builtin/scalar_unit.go builtin/scalar_unit_size.go builtin/scalar_unit_time.go
+//go:embed builtin/scalar_unit_frequency.go builtin/scalar_unit_bitrate.go
+//go:embed builtin/range.go builtin/version.go builtin/timestamp.go builtin/decoding.go
+var builtinFS embed.FS
+
+// builtinFiles are files of the builtin package to generate along with TOSCA builtin types
+var builtinFiles = map[string][]string{
+	"tosca:range":                 {"range.go"},
+	"tosca:scalar-unit":           {"scalar_unit.go", "decoding.go"},
+	"tosca:scalar-unit.size":      {"scalar_unit_size.go"},
+	"tosca:scalar-unit.time":      {"scalar_unit_time.go"},
+	"tosca:scalar-unit.frequency": {"scalar_unit_frequency.go"},
+	"tosca:scalar-unit.bitrate":   {"scalar_unit_bitrate.go"},
+	"tosca:version":               {"version.go"},
+	"tosca:timestamp":             {"timestamp.go"},
+}
+
+// delegatedMethods are methods of TOSCA builtin types that types derived from them delegate to their builtin type,
+// so they are formatted, encoded and decoded the same way
+var delegatedMethods = map[string]bool{
+	"String":        true,
+	"MarshalText":   true,
+	"UnmarshalText": true,
+	"MarshalJSON":   true,
+	"UnmarshalJSON": true,
+	"MarshalYAML":   true,
+	"UnmarshalYAML": true,
+}
+
+// builtinMethods returns the code of methods of a TOSCA builtin type or of methods delegated to the builtin type
+// for types derived from it, it returns an empty string for other types
+func (ft *fileTypes) builtinMethods(dt model.DataType) (string, error) {
+	if dt.Kind != model.DataTypeKind {
+		return "", nil
+	}
+	if _, ok := builtinFiles[dt.FQDTN]; !ok {
+		if builtin, ok := ft.derivedBuiltinType(dt); ok {
+			return ft.delegatingMethods(dt, builtin)
+		}
+	}
+	codes := make([]string, 0)
+	for _, fileName := range builtinFiles[dt.FQDTN] {
+		code, err := ft.builtinFileDeclarations(path.Join("builtin", fileName))
+		if err != nil {
+			return "", err
+		}
+		codes = append(codes, code)
+	}
+	return strings.Join(codes, "\n\n"), nil
+}
+
+// derivedBuiltinType returns the TOSCA builtin type dt is derived from, if dt is generated as a type defined
+// from its parent type
+func (ft *fileTypes) derivedBuiltinType(dt model.DataType) (model.DataType, bool) {
+	for dt.DerivedFrom != "" && len(dt.Fields) == 0 {
+		parent, ok := ft.dataTypes[dt.DerivedFrom]
+		if !ok {
+			return model.DataType{}, false
+		}
+		if _, ok := builtinFiles[parent.FQDTN]; ok {
+			return parent, true
+		}
+		dt = parent
+	}
+	return model.DataType{}, false
+}
+
+// delegatingMethods returns the code of methods of dt calling delegatedMethods of the builtin type it is derived from
+func (ft *fileTypes) delegatingMethods(dt, builtin model.DataType) (string, error) {
+	var b strings.Builder
+	for _, fileName := range builtinFiles[builtin.FQDTN] {
+		fileName = path.Join("builtin", fileName)
+		src, err := builtinFS.ReadFile(fileName)
+		if err != nil {
+			return "", fmt.Errorf("failed to read builtin code %q: %w", fileName, err)
+		}
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, fileName, src, 0)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse builtin code %q: %w", fileName, err)
+		}
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv == nil || !delegatedMethods[fd.Name.Name] {
+				continue
+			}
+			receiver, builtinReceiver := dt.Name, builtin.Name
+			recvType := fd.Recv.List[0].Type
+			if star, ok := recvType.(*ast.StarExpr); ok {
+				recvType = star.X
+				receiver, builtinReceiver = "*"+receiver, "(*"+builtinReceiver+")"
+			}
+			if ident, ok := recvType.(*ast.Ident); !ok || ident.Name != builtin.Name {
+				continue
+			}
+			args := make([]string, 0)
+			for _, param := range fd.Type.Params.List {
+				for _, name := range param.Names {
+					args = append(args, name.Name)
+				}
+			}
+			signature := string(src[fset.Position(fd.Type.Params.Pos()).Offset:fset.Position(fd.Type.End()).Offset])
+			if b.Len() > 0 {
+				b.WriteString("\n\n")
+			}
+			fmt.Fprintf(&b, "// %s calls the %s method of %s, values of %s are handled as %s values\n",
+				fd.Name.Name, fd.Name.Name, builtin.Name, dt.Name, builtin.Name)
+			fmt.Fprintf(&b, "func (v %s) %s%s {\n\treturn %s(v).%s(%s)\n}",
+				receiver, fd.Name.Name, signature, builtinReceiver, fd.Name.Name, strings.Join(args, ", "))
+		}
+	}
+	return b.String(), nil
+}
+
+// builtinFileDeclarations returns the source code of declarations of a file of the builtin package,
+// skipping the license header, the package clause and imports. Imports are recorded as required imports.
+func (ft *fileTypes) builtinFileDeclarations(fileName string) (string, error) {
+	src, err := builtinFS.ReadFile(fileName)
+	if err != nil {
+		return "", fmt.Errorf("failed to read builtin code %q: %w", fileName, err)
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, fileName, src, parser.ParseComments)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse builtin code %q: %w", fileName, err)
+	}
+	for _, i := range f.Imports {
+		importPath, err := strconv.Unquote(i.Path.Value)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse builtin code %q: %w", fileName, err)
+		}
+		ft.imports[importPath] = true
+	}
+	for _, decl := range f.Decls {
+		if gd, ok := decl.(*ast.GenDecl); ok && gd.Tok == token.IMPORT {
+			continue
+		}
+		start := decl.Pos()
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			if d.Doc != nil {
+				start = d.Doc.Pos()
+			}
+		case *ast.FuncDecl:
+			if d.Doc != nil {
+				start = d.Doc.Pos()
+			}
+		}
+		return strings.TrimSpace(string(src[fset.Position(start).Offset:])), nil
+	}
+	return "", nil
+}
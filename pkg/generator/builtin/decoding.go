@@ -0,0 +1,71 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+)
+
+var (
+	rangeType           = reflect.TypeOf(Range{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// BuiltinTypesDecodeHook is a decode hook for github.com/mitchellh/mapstructure (matching its DecodeHookFuncType)
+// that checks and decodes TOSCA builtin types values and values of types derived from them.
+//
+// Without this hook mapstructure directly copies strings into builtin types values without checking them.
+func BuiltinTypesDecodeHook(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if to.PkgPath() != rangeType.PkgPath() {
+		return data, nil
+	}
+	if to.ConvertibleTo(rangeType) && (from.Kind() == reflect.Slice || from.Kind() == reflect.Array) {
+		r, err := rangeFromSlice(data)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.ValueOf(r).Convert(to).Interface(), nil
+	}
+	if from.Kind() != reflect.String || !reflect.PtrTo(to).Implements(textUnmarshalerType) {
+		return data, nil
+	}
+	v := reflect.New(to)
+	err := v.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(reflect.ValueOf(data).String()))
+	if err != nil {
+		return nil, err
+	}
+	return v.Elem().Interface(), nil
+}
+
+func unmarshalJSONString(b []byte, unmarshalText func([]byte) error) error {
+	var s string
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	return unmarshalText([]byte(s))
+}
+
+// unmarshalYAMLString decodes a YAML string and unmarshals it using the given function
+func unmarshalYAMLString(unmarshal func(interface{}) error, unmarshalText func([]byte) error) error {
+	var s string
+	err := unmarshal(&s)
+	if err != nil {
+		return err
+	}
+	return unmarshalText([]byte(s))
+}
@@ -0,0 +1,156 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// rangeUnbounded is the TOSCA keyword used for ranges without upper bound
+const rangeUnbounded = "UNBOUNDED"
+
+// Contains returns true if n is within the range bounds (inclusive)
+func (v Range) Contains(n uint64) bool {
+	return n >= v.LowerBound && (v.Unbounded || n <= v.UpperBound)
+}
+
+// String returns the TOSCA representation of the range like "[1, 10]" or "[1, UNBOUNDED]"
+func (v Range) String() string {
+	return fmt.Sprintf("[%d, %v]", v.LowerBound, v.values()[1])
+}
+
+// values returns the TOSCA representation of the range as a list of its bounds
+func (v Range) values() []interface{} {
+	if v.Unbounded {
+		return []interface{}{v.LowerBound, rangeUnbounded}
+	}
+	return []interface{}{v.LowerBound, v.UpperBound}
+}
+
+// rangeFromValues builds a range from a list of decoded bounds
+func rangeFromValues(values []interface{}) (Range, error) {
+	if len(values) != 2 {
+		return Range{}, fmt.Errorf("invalid range %v: expecting a list of two values", values)
+	}
+	lower, unbounded, err := rangeBound(values[0])
+	if err != nil {
+		return Range{}, err
+	}
+	if unbounded {
+		return Range{}, fmt.Errorf("invalid range %v: lower bound can't be %s", values, rangeUnbounded)
+	}
+	r := Range{LowerBound: lower}
+	r.UpperBound, r.Unbounded, err = rangeBound(values[1])
+	if err != nil {
+		return Range{}, err
+	}
+	if !r.Unbounded && r.UpperBound < r.LowerBound {
+		return Range{}, fmt.Errorf("invalid range %v: upper bound is lower than lower bound", values)
+	}
+	return r, nil
+}
+
+// rangeBound converts a decoded range bound, it returns true if the bound is UNBOUNDED
+func rangeBound(value interface{}) (uint64, bool, error) {
+	switch b := value.(type) {
+	case string:
+		if strings.EqualFold(b, rangeUnbounded) {
+			return 0, true, nil
+		}
+		n, err := strconv.ParseUint(b, 10, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid range bound %q: expecting a positive integer or %s", b, rangeUnbounded)
+		}
+		return n, false, nil
+	case json.Number:
+		return rangeBound(string(b))
+	case float64:
+		if b < 0 || b != math.Trunc(b) || b > math.MaxUint64 {
+			return 0, false, fmt.Errorf("invalid range bound %v: expecting a positive integer or %s", b, rangeUnbounded)
+		}
+		return uint64(b), false, nil
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() < 0 {
+			return 0, false, fmt.Errorf("invalid range bound %v: expecting a positive integer or %s", value, rangeUnbounded)
+		}
+		return uint64(v.Int()), false, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), false, nil
+	}
+	return 0, false, fmt.Errorf("invalid range bound %v: expecting a positive integer or %s", value, rangeUnbounded)
+}
+
+// MarshalJSON implements the json.Marshaler interface, ranges are marshaled as a list of two bounds
+func (v Range) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.values())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, it fails if b is not a valid range
+func (v *Range) UnmarshalJSON(b []byte) error {
+	var values []interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	err := d.Decode(&values)
+	if err != nil {
+		return err
+	}
+	r, err := rangeFromValues(values)
+	if err != nil {
+		return err
+	}
+	*v = r
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2 and gopkg.in/yaml.v3,
+// ranges are marshaled as a list of two bounds
+func (v Range) MarshalYAML() (interface{}, error) {
+	return v.values(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2 (also supported by gopkg.in/yaml.v3),
+// it fails if the value is not a valid range
+func (v *Range) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var values []interface{}
+	err := unmarshal(&values)
+	if err != nil {
+		return err
+	}
+	r, err := rangeFromValues(values)
+	if err != nil {
+		return err
+	}
+	*v = r
+	return nil
+}
+
+// rangeFromSlice builds a range from any slice of bounds
+func rangeFromSlice(data interface{}) (Range, error) {
+	s := reflect.ValueOf(data)
+	values := make([]interface{}, s.Len())
+	for i := range values {
+		values[i] = s.Index(i).Interface()
+	}
+	return rangeFromValues(values)
+}
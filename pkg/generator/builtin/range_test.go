@@ -0,0 +1,118 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+	"gotest.tools/v3/assert"
+)
+
+func TestRange_Contains(t *testing.T) {
+	tests := []struct {
+		name string
+		r    Range
+		n    uint64
+		want bool
+	}{
+		{"Lower", Range{LowerBound: 1, UpperBound: 10}, 1, true},
+		{"Upper", Range{LowerBound: 1, UpperBound: 10}, 10, true},
+		{"BelowLower", Range{LowerBound: 1, UpperBound: 10}, 0, false},
+		{"AboveUpper", Range{LowerBound: 1, UpperBound: 10}, 11, false},
+		{"Unbounded", Range{LowerBound: 1, Unbounded: true}, 1 << 62, true},
+		{"UnboundedBelowLower", Range{LowerBound: 1, Unbounded: true}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.r.Contains(tt.n), tt.want)
+		})
+	}
+}
+
+type ranges struct {
+	Bounded   Range `mapstructure:"bounded" json:"bounded" yaml:"bounded"`
+	Unbounded Range `mapstructure:"unbounded" json:"unbounded" yaml:"unbounded"`
+}
+
+func TestRange_Marshaling(t *testing.T) {
+	want := ranges{Bounded: Range{LowerBound: 1, UpperBound: 65535}, Unbounded: Range{LowerBound: 2, Unbounded: true}}
+
+	var fromJSON ranges
+	err := json.Unmarshal([]byte(`{"bounded": [1, 65535], "unbounded": [2, "UNBOUNDED"]}`), &fromJSON)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, fromJSON, want)
+	b, err := json.Marshal(want)
+	assert.NilError(t, err)
+	assert.Equal(t, string(b), `{"bounded":[1,65535],"unbounded":[2,"UNBOUNDED"]}`)
+
+	var fromYAML ranges
+	err = yaml.Unmarshal([]byte("bounded: [ 1, 65535 ]\nunbounded: [ 2, unbounded ]\n"), &fromYAML)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, fromYAML, want)
+	b, err = yaml.Marshal(want)
+	assert.NilError(t, err)
+	assert.Equal(t, string(b), "bounded:\n  - 1\n  - 65535\nunbounded:\n  - 2\n  - UNBOUNDED\n")
+
+	assert.Equal(t, want.Bounded.String(), "[1, 65535]")
+	assert.Equal(t, want.Unbounded.String(), "[2, UNBOUNDED]")
+}
+
+func TestRange_UnmarshalErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{"NotAList", `1`, "cannot unmarshal"},
+		{"OneValue", `[1]`, "expecting a list of two values"},
+		{"UnboundedLower", `["UNBOUNDED", 2]`, "lower bound can't be UNBOUNDED"},
+		{"Negative", `[-1, 2]`, "expecting a positive integer"},
+		{"NotAnInteger", `[1.5, 2]`, "expecting a positive integer"},
+		{"UpperLowerThanLower", `[3, 2]`, "upper bound is lower than lower bound"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := Range{LowerBound: 1, UpperBound: 10}
+			r := previous
+			err := json.Unmarshal([]byte(tt.json), &r)
+			assert.ErrorContains(t, err, tt.wantErr)
+			assert.Equal(t, r, previous, "value should be left unchanged on errors")
+
+			r = previous
+			err = yaml.Unmarshal([]byte(tt.json), &r)
+			assert.Assert(t, err != nil)
+			assert.Equal(t, r, previous, "value should be left unchanged on errors")
+		})
+	}
+}
+
+func TestBuiltinTypesDecodeHook_Range(t *testing.T) {
+	got, err := BuiltinTypesDecodeHook(reflect.TypeOf([]interface{}{}), rangeType, []interface{}{1, "UNBOUNDED"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, got, Range{LowerBound: 1, Unbounded: true})
+	got, err = BuiltinTypesDecodeHook(reflect.TypeOf([]int{}), rangeType, []int{1, 10})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, got, Range{LowerBound: 1, UpperBound: 10})
+	_, err = BuiltinTypesDecodeHook(reflect.TypeOf([]int{}), rangeType, []int{10, 1})
+	assert.ErrorContains(t, err, "upper bound is lower than lower bound")
+
+	type ports Range
+	got, err = BuiltinTypesDecodeHook(reflect.TypeOf([]int{}), reflect.TypeOf(ports{}), []int{1, 10})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, got, ports{LowerBound: 1, UpperBound: 10})
+}
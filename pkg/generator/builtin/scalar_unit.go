@@ -0,0 +1,79 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// scalarUnitRegexp matches TOSCA scalar-unit values as "<scalar> <unit>"
+var scalarUnitRegexp = regexp.MustCompile(`^\s*([-+]?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+)(?:[eE][-+]?[0-9]+)?)\s*([a-zA-Z]+)\s*$`)
+
+// scalarUnitDef is the definition of a unit of a TOSCA scalar-unit type
+type scalarUnitDef struct {
+	// multiplier converts a value of this unit into the type canonical unit
+	multiplier float64
+	// bitOrByte is true for units where the case of the B letter distinguishes bytes (B) from bits (b),
+	// other letters of these units are still matched ignoring the case
+	bitOrByte bool
+}
+
+// parseScalarUnit parses a TOSCA scalar-unit value and returns it in the canonical unit of its type.
+//
+// Units are matched ignoring the case, except the B letter of units distinguishing bytes from bits.
+func parseScalarUnit(typeName, value string, units map[string]scalarUnitDef) (float64, error) {
+	m := scalarUnitRegexp.FindStringSubmatch(value)
+	if m == nil {
+		return 0, fmt.Errorf("invalid %s value %q: expecting a scalar followed by a unit", typeName, value)
+	}
+	scalar, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value %q: %w", typeName, value, err)
+	}
+	if u, ok := units[m[2]]; ok {
+		return scalar * u.multiplier, nil
+	}
+	for name, u := range units {
+		if u.bitOrByte && foldExceptB(name) == foldExceptB(m[2]) || !u.bitOrByte && strings.EqualFold(name, m[2]) {
+			return scalar * u.multiplier, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid %s value %q: unknown unit %q", typeName, value, m[2])
+}
+
+// foldExceptB returns unit in lower case except its B letters (bytes) so they are not folded into b (bits)
+func foldExceptB(unit string) string {
+	return strings.Map(func(r rune) rune {
+		if r == 'B' {
+			return r
+		}
+		return unicode.ToLower(r)
+	}, unit)
+}
+
+// compareScalars returns 0 if a == b, -1 if a < b and +1 if a > b
+func compareScalars(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,92 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+// scalarUnitBitRateUnits are units of TOSCA scalar-unit.bitrate values.
+//
+// Units are case-insensitive except their B letter: an uppercase B is a byte unit (like kBps) while a lowercase b
+// is a bit unit (like kbps).
+var scalarUnitBitRateUnits = map[string]scalarUnitDef{
+	"bps":   {multiplier: 1, bitOrByte: true},
+	"Kbps":  {multiplier: 1000, bitOrByte: true},
+	"Kibps": {multiplier: 1 << 10, bitOrByte: true},
+	"Mbps":  {multiplier: 1000000, bitOrByte: true},
+	"Mibps": {multiplier: 1 << 20, bitOrByte: true},
+	"Gbps":  {multiplier: 1000000000, bitOrByte: true},
+	"Gibps": {multiplier: 1 << 30, bitOrByte: true},
+	"Tbps":  {multiplier: 1000000000000, bitOrByte: true},
+	"Tibps": {multiplier: 1 << 40, bitOrByte: true},
+	"Bps":   {multiplier: 8, bitOrByte: true},
+	"KBps":  {multiplier: 8 * 1000, bitOrByte: true},
+	"KiBps": {multiplier: 8 << 10, bitOrByte: true},
+	"MBps":  {multiplier: 8 * 1000000, bitOrByte: true},
+	"MiBps": {multiplier: 8 << 20, bitOrByte: true},
+	"GBps":  {multiplier: 8 * 1000000000, bitOrByte: true},
+	"GiBps": {multiplier: 8 << 30, bitOrByte: true},
+	"TBps":  {multiplier: 8 * 1000000000000, bitOrByte: true},
+	"TiBps": {multiplier: 8 << 40, bitOrByte: true},
+}
+
+// ParseScalarUnitBitRate parses a TOSCA scalar-unit.bitrate value like "100 Mbps"
+func ParseScalarUnitBitRate(s string) (ScalarUnitBitRate, error) {
+	_, err := parseScalarUnit("scalar-unit.bitrate", s, scalarUnitBitRateUnits)
+	if err != nil {
+		return "", err
+	}
+	return ScalarUnitBitRate(s), nil
+}
+
+// BitsPerSecond returns the bit rate in bits per second
+func (v ScalarUnitBitRate) BitsPerSecond() (float64, error) {
+	f, err := parseScalarUnit("scalar-unit.bitrate", string(v), scalarUnitBitRateUnits)
+	if err != nil {
+		return 0, err
+	}
+	return f, nil
+}
+
+// Compare compares two scalar-unit.bitrate values, it returns 0 if v == o, -1 if v < o and +1 if v > o
+func (v ScalarUnitBitRate) Compare(o ScalarUnitBitRate) (int, error) {
+	a, err := parseScalarUnit("scalar-unit.bitrate", string(v), scalarUnitBitRateUnits)
+	if err != nil {
+		return 0, err
+	}
+	b, err := parseScalarUnit("scalar-unit.bitrate", string(o), scalarUnitBitRateUnits)
+	if err != nil {
+		return 0, err
+	}
+	return compareScalars(a, b), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, it fails if text is not a valid scalar-unit.bitrate value
+func (v *ScalarUnitBitRate) UnmarshalText(text []byte) error {
+	p, err := ParseScalarUnitBitRate(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, it fails if b is not a valid scalar-unit.bitrate value
+func (v *ScalarUnitBitRate) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONString(b, v.UnmarshalText)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2 (also supported by gopkg.in/yaml.v3),
+// it fails if the value is not a valid scalar-unit.bitrate value
+func (v *ScalarUnitBitRate) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLString(unmarshal, v.UnmarshalText)
+}
@@ -0,0 +1,75 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+// scalarUnitFrequencyUnits are units of TOSCA scalar-unit.frequency values, they are case-insensitive
+var scalarUnitFrequencyUnits = map[string]scalarUnitDef{
+	"Hz":  {multiplier: 1},
+	"kHz": {multiplier: 1000},
+	"MHz": {multiplier: 1000000},
+	"GHz": {multiplier: 1000000000},
+}
+
+// ParseScalarUnitFrequency parses a TOSCA scalar-unit.frequency value like "2.4 GHz"
+func ParseScalarUnitFrequency(s string) (ScalarUnitFrequency, error) {
+	_, err := parseScalarUnit("scalar-unit.frequency", s, scalarUnitFrequencyUnits)
+	if err != nil {
+		return "", err
+	}
+	return ScalarUnitFrequency(s), nil
+}
+
+// Hz returns the frequency in Hertz
+func (v ScalarUnitFrequency) Hz() (float64, error) {
+	f, err := parseScalarUnit("scalar-unit.frequency", string(v), scalarUnitFrequencyUnits)
+	if err != nil {
+		return 0, err
+	}
+	return f, nil
+}
+
+// Compare compares two scalar-unit.frequency values, it returns 0 if v == o, -1 if v < o and +1 if v > o
+func (v ScalarUnitFrequency) Compare(o ScalarUnitFrequency) (int, error) {
+	a, err := parseScalarUnit("scalar-unit.frequency", string(v), scalarUnitFrequencyUnits)
+	if err != nil {
+		return 0, err
+	}
+	b, err := parseScalarUnit("scalar-unit.frequency", string(o), scalarUnitFrequencyUnits)
+	if err != nil {
+		return 0, err
+	}
+	return compareScalars(a, b), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, it fails if text is not a valid scalar-unit.frequency value
+func (v *ScalarUnitFrequency) UnmarshalText(text []byte) error {
+	p, err := ParseScalarUnitFrequency(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, it fails if b is not a valid scalar-unit.frequency value
+func (v *ScalarUnitFrequency) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONString(b, v.UnmarshalText)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2 (also supported by gopkg.in/yaml.v3),
+// it fails if the value is not a valid scalar-unit.frequency value
+func (v *ScalarUnitFrequency) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLString(unmarshal, v.UnmarshalText)
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"fmt"
+	"math"
+)
+
+// scalarUnitSizeUnits are units of TOSCA scalar-unit.size values, they are case-insensitive
+var scalarUnitSizeUnits = map[string]scalarUnitDef{
+	"B":   {multiplier: 1},
+	"kB":  {multiplier: 1000},
+	"KiB": {multiplier: 1 << 10},
+	"MB":  {multiplier: 1000000},
+	"MiB": {multiplier: 1 << 20},
+	"GB":  {multiplier: 1000000000},
+	"GiB": {multiplier: 1 << 30},
+	"TB":  {multiplier: 1000000000000},
+	"TiB": {multiplier: 1 << 40},
+}
+
+// ParseScalarUnitSize parses a TOSCA scalar-unit.size value like "4 GiB"
+func ParseScalarUnitSize(s string) (ScalarUnitSize, error) {
+	_, err := parseScalarUnit("scalar-unit.size", s, scalarUnitSizeUnits)
+	if err != nil {
+		return "", err
+	}
+	return ScalarUnitSize(s), nil
+}
+
+// Bytes returns the size in bytes
+func (v ScalarUnitSize) Bytes() (uint64, error) {
+	f, err := parseScalarUnit("scalar-unit.size", string(v), scalarUnitSizeUnits)
+	if err != nil {
+		return 0, err
+	}
+	if f < 0 {
+		return 0, fmt.Errorf("invalid scalar-unit.size value %q: sizes can't be negative", v)
+	}
+	return uint64(math.Round(f)), nil
+}
+
+// Compare compares two scalar-unit.size values, it returns 0 if v == o, -1 if v < o and +1 if v > o
+func (v ScalarUnitSize) Compare(o ScalarUnitSize) (int, error) {
+	a, err := parseScalarUnit("scalar-unit.size", string(v), scalarUnitSizeUnits)
+	if err != nil {
+		return 0, err
+	}
+	b, err := parseScalarUnit("scalar-unit.size", string(o), scalarUnitSizeUnits)
+	if err != nil {
+		return 0, err
+	}
+	return compareScalars(a, b), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, it fails if text is not a valid scalar-unit.size value
+func (v *ScalarUnitSize) UnmarshalText(text []byte) error {
+	p, err := ParseScalarUnitSize(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, it fails if b is not a valid scalar-unit.size value
+func (v *ScalarUnitSize) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONString(b, v.UnmarshalText)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2 (also supported by gopkg.in/yaml.v3),
+// it fails if the value is not a valid scalar-unit.size value
+func (v *ScalarUnitSize) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLString(unmarshal, v.UnmarshalText)
+}
@@ -0,0 +1,195 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"gotest.tools/v3/assert"
+)
+
+func TestScalarUnitSize_Bytes(t *testing.T) {
+	tests := []struct {
+		value   ScalarUnitSize
+		want    uint64
+		wantErr bool
+	}{
+		{"4 GiB", 4 << 30, false},
+		{"4 GB", 4000000000, false},
+		{"1.5kb", 1500, false},
+		{" 10 kib ", 10240, false},
+		{"12 B", 12, false},
+		{"12", 0, true},
+		{"12 KB0", 0, true},
+		{"-1 MB", 0, true},
+		{"1 PB", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.value), func(t *testing.T) {
+			got, err := tt.value.Bytes()
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestScalarUnitTime_Duration(t *testing.T) {
+	tests := []struct {
+		value   ScalarUnitTime
+		want    time.Duration
+		wantErr bool
+	}{
+		{"500 ms", 500 * time.Millisecond, false},
+		{"1.5 h", 90 * time.Minute, false},
+		{"2 D", 48 * time.Hour, false},
+		{"10 us", 10 * time.Microsecond, false},
+		{"3 m", 3 * time.Minute, false},
+		{"1000000 d", 0, true},
+		{"3 weeks", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.value), func(t *testing.T) {
+			got, err := tt.value.Duration()
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestScalarUnitFrequency_Hz(t *testing.T) {
+	got, err := ScalarUnitFrequency("2.4 ghz").Hz()
+	assert.NilError(t, err)
+	assert.Equal(t, got, 2.4e9)
+	_, err = ScalarUnitFrequency("2.4 GB").Hz()
+	assert.ErrorContains(t, err, `unknown unit "GB"`)
+}
+
+func TestScalarUnitBitRate_BitsPerSecond(t *testing.T) {
+	tests := []struct {
+		value   ScalarUnitBitRate
+		want    float64
+		wantErr bool
+	}{
+		{"100 Mbps", 100e6, false},
+		{"100 mbps", 100e6, false},
+		{"1 Kibps", 1024, false},
+		{"1 MBps", 8e6, false},
+		{"1 MiBps", 8 << 20, false},
+		{"1 mibps", 1 << 20, false},
+		{"1 kbps", 1000, false},
+		{"1 kBps", 8000, false},
+		{"1 KBps", 8000, false},
+		{"1 mBps", 8e6, false},
+		{"1 GiBps", 8 << 30, false},
+		{"1 giBps", 8 << 30, false},
+		{"1 gibps", 1 << 30, false},
+		{"1 BPS", 8, false},
+		{"1 PBps", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.value), func(t *testing.T) {
+			got, err := tt.value.BitsPerSecond()
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestScalarUnit_Compare(t *testing.T) {
+	c, err := ScalarUnitSize("1 GiB").Compare("1 GB")
+	assert.NilError(t, err)
+	assert.Equal(t, c, 1)
+	c, err = ScalarUnitTime("60 s").Compare("1 m")
+	assert.NilError(t, err)
+	assert.Equal(t, c, 0)
+	c, err = ScalarUnitBitRate("1 Kbps").Compare("1 KBps")
+	assert.NilError(t, err)
+	assert.Equal(t, c, -1)
+	_, err = ScalarUnitFrequency("1 Hz").Compare("1 Hertz")
+	assert.Assert(t, err != nil)
+}
+
+type scalarUnits struct {
+	Size      ScalarUnitSize      `mapstructure:"size" json:"size,omitempty" yaml:"size,omitempty"`
+	Time      ScalarUnitTime      `mapstructure:"time" json:"time,omitempty" yaml:"time,omitempty"`
+	Frequency ScalarUnitFrequency `mapstructure:"frequency" json:"frequency,omitempty" yaml:"frequency,omitempty"`
+	BitRate   ScalarUnitBitRate   `mapstructure:"bitrate" json:"bitrate,omitempty" yaml:"bitrate,omitempty"`
+}
+
+func TestScalarUnit_Unmarshal(t *testing.T) {
+	want := scalarUnits{Size: "4 GiB", Time: "10 s", Frequency: "1 GHz", BitRate: "1 Gbps"}
+
+	var fromJSON scalarUnits
+	err := json.Unmarshal([]byte(`{"size": "4 GiB", "time": "10 s", "frequency": "1 GHz", "bitrate": "1 Gbps"}`), &fromJSON)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, fromJSON, want)
+	err = json.Unmarshal([]byte(`{"size": "4 GHz"}`), &fromJSON)
+	assert.ErrorContains(t, err, `invalid scalar-unit.size value "4 GHz"`)
+	err = json.Unmarshal([]byte(`{"size": 4}`), &fromJSON)
+	assert.Assert(t, err != nil)
+
+	var fromYAML scalarUnits
+	err = yaml.Unmarshal([]byte("size: 4 GiB\ntime: 10 s\nfrequency: 1 GHz\nbitrate: 1 Gbps\n"), &fromYAML)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, fromYAML, want)
+	err = yaml.Unmarshal([]byte("time: 10 GiB\n"), &fromYAML)
+	assert.ErrorContains(t, err, `invalid scalar-unit.time value "10 GiB"`)
+}
+
+func TestBuiltinTypesDecodeHook(t *testing.T) {
+	tests := []struct {
+		name    string
+		to      reflect.Type
+		data    interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{"Size", reflect.TypeOf(ScalarUnitSize("")), "4 GiB", ScalarUnitSize("4 GiB"), false},
+		{"Time", reflect.TypeOf(ScalarUnitTime("")), "10 s", ScalarUnitTime("10 s"), false},
+		{"Frequency", reflect.TypeOf(ScalarUnitFrequency("")), "1 GHz", ScalarUnitFrequency("1 GHz"), false},
+		{"BitRate", reflect.TypeOf(ScalarUnitBitRate("")), "1 Gbps", ScalarUnitBitRate("1 Gbps"), false},
+		{"InvalidSize", reflect.TypeOf(ScalarUnitSize("")), "4 Hz", nil, true},
+		{"OtherType", reflect.TypeOf(""), "4 Hz", "4 Hz", false},
+		{"NotAString", reflect.TypeOf(ScalarUnitSize("")), 4, 4, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuiltinTypesDecodeHook(reflect.TypeOf(tt.data), tt.to, tt.data)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// scalarUnitTimeUnits are units of TOSCA scalar-unit.time values in nanoseconds, they are case-insensitive
+var scalarUnitTimeUnits = map[string]scalarUnitDef{
+	"d":  {multiplier: float64(24 * time.Hour)},
+	"h":  {multiplier: float64(time.Hour)},
+	"m":  {multiplier: float64(time.Minute)},
+	"s":  {multiplier: float64(time.Second)},
+	"ms": {multiplier: float64(time.Millisecond)},
+	"us": {multiplier: float64(time.Microsecond)},
+	"ns": {multiplier: float64(time.Nanosecond)},
+}
+
+// ParseScalarUnitTime parses a TOSCA scalar-unit.time value like "500 ms"
+func ParseScalarUnitTime(s string) (ScalarUnitTime, error) {
+	_, err := parseScalarUnit("scalar-unit.time", s, scalarUnitTimeUnits)
+	if err != nil {
+		return "", err
+	}
+	return ScalarUnitTime(s), nil
+}
+
+// Duration returns the value as a time.Duration
+func (v ScalarUnitTime) Duration() (time.Duration, error) {
+	f, err := parseScalarUnit("scalar-unit.time", string(v), scalarUnitTimeUnits)
+	if err != nil {
+		return 0, err
+	}
+	if f > math.MaxInt64 || f < math.MinInt64 {
+		return 0, fmt.Errorf("invalid scalar-unit.time value %q: out of time.Duration range", v)
+	}
+	return time.Duration(math.Round(f)), nil
+}
+
+// Compare compares two scalar-unit.time values, it returns 0 if v == o, -1 if v < o and +1 if v > o
+func (v ScalarUnitTime) Compare(o ScalarUnitTime) (int, error) {
+	a, err := parseScalarUnit("scalar-unit.time", string(v), scalarUnitTimeUnits)
+	if err != nil {
+		return 0, err
+	}
+	b, err := parseScalarUnit("scalar-unit.time", string(o), scalarUnitTimeUnits)
+	if err != nil {
+		return 0, err
+	}
+	return compareScalars(a, b), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, it fails if text is not a valid scalar-unit.time value
+func (v *ScalarUnitTime) UnmarshalText(text []byte) error {
+	p, err := ParseScalarUnitTime(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, it fails if b is not a valid scalar-unit.time value
+func (v *ScalarUnitTime) UnmarshalJSON(b []byte) error {
+	return unmarshalJSONString(b, v.UnmarshalText)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2 (also supported by gopkg.in/yaml.v3),
+// it fails if the value is not a valid scalar-unit.time value
+func (v *ScalarUnitTime) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLString(unmarshal, v.UnmarshalText)
+}
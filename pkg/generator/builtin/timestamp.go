@@ -0,0 +1,160 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timestampRegexp matches YAML 1.1 timestamps as defined in https://yaml.org/type/timestamp.html
+var timestampRegexp = regexp.MustCompile(`^([0-9]{4})-([0-9]{1,2})-([0-9]{1,2})(?:(?:[Tt]|[ \t]+)([0-9]{1,2}):([0-9]{2}):([0-9]{2})(?:\.([0-9]*))?(?:[ \t]*(Z|[-+][0-9]{1,2}(?::[0-9]{2})?))?)?$`)
+
+// ParseTimestamp parses a TOSCA timestamp using the YAML 1.1 timestamp grammar used by TOSCA
+// like "2001-12-14t21:59:43.10-05:00", "2001-12-14 21:59:43.10 -5" or "2002-12-14".
+//
+// Timestamps without time zone are considered as UTC.
+func ParseTimestamp(s string) (Timestamp, error) {
+	m := timestampRegexp.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Timestamp{}, fmt.Errorf("invalid timestamp %q", s)
+	}
+	n := make([]int, 6)
+	for i := range n {
+		if m[i+1] != "" {
+			n[i], _ = strconv.Atoi(m[i+1])
+		}
+	}
+	if n[1] < 1 || n[1] > 12 {
+		return Timestamp{}, fmt.Errorf("invalid timestamp %q: month out of range", s)
+	}
+	if n[2] < 1 || n[2] > daysIn(time.Month(n[1]), n[0]) {
+		return Timestamp{}, fmt.Errorf("invalid timestamp %q: day out of range", s)
+	}
+	if n[3] > 23 || n[4] > 59 || n[5] > 59 {
+		return Timestamp{}, fmt.Errorf("invalid timestamp %q: time out of range", s)
+	}
+	nsec := 0
+	if m[7] != "" {
+		frac := m[7]
+		if len(frac) > 9 {
+			frac = frac[:9]
+		}
+		nsec, _ = strconv.Atoi(frac + strings.Repeat("0", 9-len(frac)))
+	}
+	loc := time.UTC
+	if m[8] != "" && m[8] != "Z" {
+		tz := strings.SplitN(m[8][1:], ":", 2)
+		hours, _ := strconv.Atoi(tz[0])
+		minutes := 0
+		if len(tz) == 2 {
+			minutes, _ = strconv.Atoi(tz[1])
+		}
+		if hours > 23 || minutes > 59 {
+			return Timestamp{}, fmt.Errorf("invalid timestamp %q: time zone out of range", s)
+		}
+		offset := hours*3600 + minutes*60
+		if m[8][0] == '-' {
+			offset = -offset
+		}
+		loc = time.FixedZone("", offset)
+	}
+	return Timestamp(time.Date(n[0], time.Month(n[1]), n[2], n[3], n[4], n[5], nsec, loc)), nil
+}
+
+// daysIn returns the number of days of a month
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Time returns the timestamp as a time.Time
+func (v Timestamp) Time() time.Time {
+	return time.Time(v)
+}
+
+// String returns the timestamp formatted using RFC 3339
+func (v Timestamp) String() string {
+	return time.Time(v).Format(time.RFC3339Nano)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, timestamps are formatted using RFC 3339
+func (v Timestamp) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, it accepts every YAML 1.1 timestamp forms
+func (v *Timestamp) UnmarshalText(text []byte) error {
+	p, err := ParseTimestamp(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, it accepts every YAML 1.1 timestamp forms
+func (v *Timestamp) UnmarshalJSON(b []byte) error {
+	var s string
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2 and gopkg.in/yaml.v3
+func (v Timestamp) MarshalYAML() (interface{}, error) {
+	return v.String(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2 (also supported by gopkg.in/yaml.v3),
+// it accepts every YAML 1.1 timestamp forms
+func (v *Timestamp) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	err := unmarshal(&s)
+	if err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// TimestampDecodeHook is a decode hook for github.com/mitchellh/mapstructure (matching its DecodeHookFuncType)
+// that decodes timestamps, and values of types derived from Timestamp, from strings using every YAML 1.1
+// timestamp forms or from time.Time values.
+func TimestampDecodeHook(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	timestampType := reflect.TypeOf(Timestamp{})
+	if to.PkgPath() != timestampType.PkgPath() || !to.ConvertibleTo(timestampType) {
+		return data, nil
+	}
+	var ts Timestamp
+	switch d := data.(type) {
+	case string:
+		var err error
+		ts, err = ParseTimestamp(d)
+		if err != nil {
+			return nil, err
+		}
+	case time.Time:
+		ts = Timestamp(d)
+	default:
+		return data, nil
+	}
+	return reflect.ValueOf(ts).Convert(to).Interface(), nil
+}
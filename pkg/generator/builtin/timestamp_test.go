@@ -0,0 +1,117 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"gotest.tools/v3/assert"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	minus5 := time.FixedZone("", -5*3600)
+	tests := []struct {
+		timestamp string
+		want      time.Time
+		wantErr   bool
+	}{
+		{"2001-12-14t21:59:43.10-05:00", time.Date(2001, 12, 14, 21, 59, 43, 100000000, minus5), false},
+		{"2001-12-14 21:59:43.10 -5", time.Date(2001, 12, 14, 21, 59, 43, 100000000, minus5), false},
+		{"2001-12-15T02:59:43.1Z", time.Date(2001, 12, 15, 2, 59, 43, 100000000, time.UTC), false},
+		{"2001-12-15 2:59:43.10", time.Date(2001, 12, 15, 2, 59, 43, 100000000, time.UTC), false},
+		{"2002-12-14", time.Date(2002, 12, 14, 0, 0, 0, 0, time.UTC), false},
+		{"2002-12-14 +01", time.Time{}, true},
+		{"14/12/2002", time.Time{}, true},
+		{"2004-02-29", time.Date(2004, 2, 29, 0, 0, 0, 0, time.UTC), false},
+		{"2003-02-29", time.Time{}, true},
+		{"2002-13-14", time.Time{}, true},
+		{"2002-00-14", time.Time{}, true},
+		{"2002-12-45", time.Time{}, true},
+		{"2002-12-00", time.Time{}, true},
+		{"2002-12-14 25:00:00", time.Time{}, true},
+		{"2002-12-14 21:60:00", time.Time{}, true},
+		{"2002-12-14 21:59:60", time.Time{}, true},
+		{"2002-12-14 21:59:43 +24", time.Time{}, true},
+		{"2002-12-14 21:59:43 +01:60", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.timestamp, func(t *testing.T) {
+			got, err := ParseTimestamp(tt.timestamp)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Assert(t, got.Time().Equal(tt.want), "got %v, want %v", got, tt.want)
+			_, gotOffset := got.Time().Zone()
+			_, wantOffset := tt.want.Zone()
+			assert.Equal(t, gotOffset, wantOffset)
+		})
+	}
+}
+
+type timestamps struct {
+	Short Timestamp `mapstructure:"short" json:"short" yaml:"short"`
+	Long  Timestamp `mapstructure:"long" json:"long" yaml:"long"`
+}
+
+func TestTimestamp_Marshaling(t *testing.T) {
+	want := timestamps{
+		Short: Timestamp(time.Date(2002, 12, 14, 0, 0, 0, 0, time.UTC)),
+		Long:  Timestamp(time.Date(2001, 12, 14, 21, 59, 43, 100000000, time.FixedZone("", -5*3600))),
+	}
+
+	var fromJSON timestamps
+	err := json.Unmarshal([]byte(`{"short": "2002-12-14", "long": "2001-12-14 21:59:43.10 -5"}`), &fromJSON)
+	assert.NilError(t, err)
+	assert.Equal(t, fromJSON.String(), want.String())
+	b, err := json.Marshal(want)
+	assert.NilError(t, err)
+	assert.Equal(t, string(b), `{"short":"2002-12-14T00:00:00Z","long":"2001-12-14T21:59:43.1-05:00"}`)
+	err = json.Unmarshal([]byte(`{"short": "yesterday"}`), &fromJSON)
+	assert.ErrorContains(t, err, `invalid timestamp "yesterday"`)
+
+	var fromYAML timestamps
+	err = yaml.Unmarshal([]byte("short: 2002-12-14\nlong: 2001-12-14 21:59:43.10 -5\n"), &fromYAML)
+	assert.NilError(t, err)
+	assert.Equal(t, fromYAML.String(), want.String())
+	b, err = yaml.Marshal(want)
+	assert.NilError(t, err)
+	assert.Equal(t, string(b), "short: \"2002-12-14T00:00:00Z\"\nlong: \"2001-12-14T21:59:43.1-05:00\"\n")
+
+	timestampType := reflect.TypeOf(Timestamp{})
+	got, err := TimestampDecodeHook(reflect.TypeOf(""), timestampType, "2001-12-14 21:59:43.10 -5")
+	assert.NilError(t, err)
+	assert.Equal(t, got.(Timestamp).String(), want.Long.String())
+	got, err = TimestampDecodeHook(reflect.TypeOf(time.Time{}), timestampType, time.Time(want.Short))
+	assert.NilError(t, err)
+	assert.Equal(t, got.(Timestamp).String(), want.Short.String())
+
+	type expiration Timestamp
+	got, err = TimestampDecodeHook(reflect.TypeOf(""), reflect.TypeOf(expiration{}), "2001-12-14 21:59:43.10 -5")
+	assert.NilError(t, err)
+	assert.Equal(t, Timestamp(got.(expiration)).String(), want.Long.String())
+	got, err = TimestampDecodeHook(reflect.TypeOf(time.Time{}), reflect.TypeOf(time.Time{}), time.Time(want.Short))
+	assert.NilError(t, err)
+	assert.Equal(t, got.(time.Time), time.Time(want.Short))
+}
+
+func (ts timestamps) String() string {
+	return ts.Short.String() + " " + ts.Long.String()
+}
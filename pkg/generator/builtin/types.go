@@ -0,0 +1,67 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package builtin contains methods generated by tdt2go along with TOSCA builtin types.
+//
+// Files of this package (except this one and tests) are embedded into the generator and their declarations are
+// copied into generated files, having them in a regular package allows to compile and test them.
+package builtin
+
+import (
+	"time"
+)
+
+// Declarations below mirror builtin types as generated by tdt2go
+
+// Range is the generated representation of tosca:range data type
+type Range struct {
+	// LowerBound is the lower bound of the range
+	LowerBound uint64
+	// UpperBound is the upper bound of the range, it is ignored if the range is unbounded
+	UpperBound uint64
+	// Unbounded is true if the range has no upper bound (UNBOUNDED TOSCA keyword)
+	Unbounded bool
+}
+
+// ScalarUnit is the generated representation of tosca:scalar-unit data type
+type ScalarUnit string
+
+// ScalarUnitBitRate is the generated representation of tosca:scalar-unit.bitrate data type
+type ScalarUnitBitRate ScalarUnit
+
+// ScalarUnitFrequency is the generated representation of tosca:scalar-unit.frequency data type
+type ScalarUnitFrequency ScalarUnit
+
+// ScalarUnitSize is the generated representation of tosca:scalar-unit.size data type
+type ScalarUnitSize ScalarUnit
+
+// ScalarUnitTime is the generated representation of tosca:scalar-unit.time data type
+type ScalarUnitTime ScalarUnit
+
+// Version is the generated representation of tosca:version data type
+type Version struct {
+	// Major is the major version number
+	Major uint64
+	// Minor is the minor version number
+	Minor uint64
+	// Fix is the fix version number
+	Fix uint64
+	// Qualifier is the optional version qualifier (like alpha or beta)
+	Qualifier string
+	// Build is the optional build version number of a qualified version
+	Build uint64
+}
+
+// Timestamp is the generated representation of tosca:timestamp data type
+type Timestamp time.Time
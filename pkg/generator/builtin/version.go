@@ -0,0 +1,121 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// versionRegexp matches TOSCA versions as <major>.<minor>[.<fix>[.<qualifier>[-<build>]]]
+var versionRegexp = regexp.MustCompile(`^([0-9]+)\.([0-9]+)(?:\.([0-9]+)(?:\.([0-9A-Za-z_]+)(?:-([0-9]+))?)?)?$`)
+
+// ParseVersion parses a TOSCA version like "1.0", "2.1.3" or "2.1.3.beta-2"
+func ParseVersion(s string) (Version, error) {
+	m := versionRegexp.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Version{}, fmt.Errorf("invalid version %q: expecting <major>.<minor>[.<fix>[.<qualifier>[-<build>]]]", s)
+	}
+	numbers := make([]uint64, 0, 4)
+	for _, n := range []string{m[1], m[2], m[3], m[5]} {
+		if n == "" {
+			numbers = append(numbers, 0)
+			continue
+		}
+		i, err := strconv.ParseUint(n, 10, 64)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid version %q: %w", s, err)
+		}
+		numbers = append(numbers, i)
+	}
+	return Version{Major: numbers[0], Minor: numbers[1], Fix: numbers[2], Qualifier: m[4], Build: numbers[3]}, nil
+}
+
+// String returns the TOSCA representation of the version, the fix version is always included
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Fix)
+	if v.Qualifier != "" {
+		s += "." + v.Qualifier
+		if v.Build != 0 {
+			s += fmt.Sprintf("-%d", v.Build)
+		}
+	}
+	return s
+}
+
+// Compare compares two versions, it returns 0 if v == o, -1 if v < o and +1 if v > o.
+//
+// As defined by TOSCA, major, minor and fix versions are compared in sequence, versions with a qualifier are
+// considered older than versions without qualifier and build versions are compared only for identical qualifiers.
+// Different qualifiers are compared lexically.
+func (v Version) Compare(o Version) int {
+	for _, c := range [][2]uint64{{v.Major, o.Major}, {v.Minor, o.Minor}, {v.Fix, o.Fix}} {
+		if c[0] != c[1] {
+			return compareVersionNumbers(c[0], c[1])
+		}
+	}
+	switch {
+	case v.Qualifier == o.Qualifier:
+		return compareVersionNumbers(v.Build, o.Build)
+	case v.Qualifier == "":
+		return 1
+	case o.Qualifier == "":
+		return -1
+	}
+	return strings.Compare(v.Qualifier, o.Qualifier)
+}
+
+func compareVersionNumbers(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// MarshalText implements the encoding.TextMarshaler interface
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, it fails if text is not a valid version
+func (v *Version) UnmarshalText(text []byte) error {
+	p, err := ParseVersion(string(text))
+	if err != nil {
+		return err
+	}
+	*v = p
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, it fails if b is not a valid version.
+//
+// Versions are accepted as JSON strings or numbers (like 1.0).
+func (v *Version) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] != '"' {
+		return v.UnmarshalText(b)
+	}
+	return unmarshalJSONString(b, v.UnmarshalText)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2 (also supported by gopkg.in/yaml.v3),
+// it fails if the value is not a valid version
+func (v *Version) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLString(unmarshal, v.UnmarshalText)
+}
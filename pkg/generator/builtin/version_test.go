@@ -0,0 +1,118 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+	"gotest.tools/v3/assert"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    Version
+		wantErr bool
+	}{
+		{"1.0", Version{Major: 1}, false},
+		{"2.1.3", Version{Major: 2, Minor: 1, Fix: 3}, false},
+		{"2.1.3.beta", Version{Major: 2, Minor: 1, Fix: 3, Qualifier: "beta"}, false},
+		{"2.1.3.beta-2", Version{Major: 2, Minor: 1, Fix: 3, Qualifier: "beta", Build: 2}, false},
+		{"1", Version{}, true},
+		{"1.0-2", Version{}, true},
+		{"1.0.beta", Version{}, true},
+		{"v1.0.0", Version{}, true},
+		{"1.0.0.beta-", Version{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := ParseVersion(tt.version)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestVersion_String(t *testing.T) {
+	assert.Equal(t, Version{Major: 1}.String(), "1.0.0")
+	assert.Equal(t, Version{Major: 2, Minor: 1, Fix: 3, Qualifier: "beta"}.String(), "2.1.3.beta")
+	assert.Equal(t, Version{Major: 2, Minor: 1, Fix: 3, Qualifier: "beta", Build: 2}.String(), "2.1.3.beta-2")
+}
+
+func TestVersion_Compare(t *testing.T) {
+	tests := []struct {
+		v    string
+		o    string
+		want int
+	}{
+		{"1.0", "1.0.0", 0},
+		{"1.0", "1.1", -1},
+		{"2.0", "1.10", 1},
+		{"1.10", "1.9", 1},
+		{"1.0.1", "1.0.0", 1},
+		{"1.0.0.alpha", "1.0.0", -1},
+		{"1.0.0", "1.0.0.alpha", 1},
+		{"1.0.0.beta-2", "1.0.0.beta-10", -1},
+		{"1.0.0.beta-2", "1.0.0.alpha-10", 1},
+		{"1.0.0.beta-2", "1.0.0.beta-2", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v+"_"+tt.o, func(t *testing.T) {
+			v, err := ParseVersion(tt.v)
+			assert.NilError(t, err)
+			o, err := ParseVersion(tt.o)
+			assert.NilError(t, err)
+			assert.Equal(t, v.Compare(o), tt.want)
+		})
+	}
+}
+
+type versions struct {
+	Version Version   `mapstructure:"version" json:"version" yaml:"version"`
+	Others  []Version `mapstructure:"others" json:"others" yaml:"others"`
+}
+
+func TestVersion_Marshaling(t *testing.T) {
+	want := versions{Version: Version{Major: 1, Minor: 10}, Others: []Version{{Major: 2, Qualifier: "rc", Build: 1}}}
+
+	var fromJSON versions
+	err := json.Unmarshal([]byte(`{"version": 1.10, "others": ["2.0.0.rc-1"]}`), &fromJSON)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, fromJSON, want)
+	b, err := json.Marshal(want)
+	assert.NilError(t, err)
+	assert.Equal(t, string(b), `{"version":"1.10.0","others":["2.0.0.rc-1"]}`)
+	err = json.Unmarshal([]byte(`{"version": "1.a"}`), &fromJSON)
+	assert.ErrorContains(t, err, `invalid version "1.a"`)
+
+	var fromYAML versions
+	err = yaml.Unmarshal([]byte("version: 1.10\nothers: [ 2.0.0.rc-1 ]\n"), &fromYAML)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, fromYAML, want)
+	b, err = yaml.Marshal(want)
+	assert.NilError(t, err)
+	assert.Equal(t, string(b), "version: 1.10.0\nothers:\n  - 2.0.0.rc-1\n")
+
+	got, err := BuiltinTypesDecodeHook(reflect.TypeOf(""), reflect.TypeOf(Version{}), "1.10")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, got, want.Version)
+}
@@ -0,0 +1,437 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ystia/tdt2go/pkg/generator/builtin"
+	"github.com/ystia/tdt2go/pkg/model"
+)
+
+// errUnsupportedDefault is returned when a Go literal can't be generated for a given type
+var errUnsupportedDefault = errors.New("default values are not supported for this type")
+
+// defaultsGenerator generates SetDefaults methods and constructors applying TOSCA default values on data types.
+//
+// All complex data types generated in the file have a SetDefaults method.
+type defaultsGenerator struct {
+	*fileTypes
+}
+
+// defaultsMethods generates the constructor and the SetDefaults method of a data type,
+// nothing is generated for data types derived from primitive types.
+func (dg *defaultsGenerator) defaultsMethods(dt model.DataType) (string, error) {
+	if !dg.hasDefaultsMethod(dt.Name) {
+		return "", nil
+	}
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "// New%[1]s returns a new %[1]s initialized with TOSCA default values\n", dt.Name)
+	fmt.Fprintf(b, "func New%[1]s() *%[1]s {\nv := &%[1]s{}\nv.SetDefaults()\nreturn v\n}\n\n", dt.Name)
+	fmt.Fprintf(b, "// SetDefaults sets TOSCA default values on %s fields having a zero value\n", dt.Name)
+	fmt.Fprintf(b, "func (v *%s) SetDefaults() {\n", dt.Name)
+	// Refined default values should be set before the parent ones on promoted fields
+	for _, f := range dt.Fields {
+		if f.Refinement && f.Default != nil {
+			err := dg.writeFieldDefault(b, f)
+			if err != nil {
+				return "", fmt.Errorf("property %q of data type %q: %w", fieldOriginalName(f), dt.FQDTN, err)
+			}
+		}
+	}
+	if dt.DerivedFrom != "" {
+		if isStructType(dt) {
+			dg.writeNestedDefaults(b, "&v."+embeddedFieldName(dt.DerivedFrom), dt.DerivedFrom)
+		} else {
+			dg.writeNestedDefaults(b, fmt.Sprintf("(*%s)(v)", dt.DerivedFrom), dt.DerivedFrom)
+		}
+	}
+	for _, f := range dt.Fields {
+		if f.Refinement {
+			continue
+		}
+		if f.Default != nil {
+			err := dg.writeFieldDefault(b, f)
+			if err != nil {
+				return "", fmt.Errorf("property %q of data type %q: %w", fieldOriginalName(f), dt.FQDTN, err)
+			}
+		}
+		if _, ok := dg.dataTypes[f.Type]; ok && dg.hasDefaultsMethod(f.Type) {
+			fmt.Fprintf(b, "v.%s.SetDefaults()\n", f.Name)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+// hasDefaultsMethod returns true if the given type is a complex data type generated in the file or may
+// be a complex data type generated elsewhere
+func (dg *defaultsGenerator) hasDefaultsMethod(goType string) bool {
+	if _, ok := dg.enums[goType]; ok || isBuiltinType(goType) {
+		return false
+	}
+	dt, ok := dg.dataTypes[goType]
+	return !ok || dt.UnderlyingType == ""
+}
+
+func (dg *defaultsGenerator) writeNestedDefaults(b *strings.Builder, ptrExpr, goType string) {
+	if !dg.hasDefaultsMethod(goType) {
+		return
+	}
+	if _, ok := dg.dataTypes[goType]; ok {
+		fmt.Fprintf(b, "%s.SetDefaults()\n", strings.TrimPrefix(ptrExpr, "&"))
+		return
+	}
+	fmt.Fprintf(b, "if d, ok := interface{}(%s).(interface{ SetDefaults() }); ok {\nd.SetDefaults()\n}\n", ptrExpr)
+}
+
+func (dg *defaultsGenerator) writeFieldDefault(b *strings.Builder, f model.Field) error {
+	expr := "v." + f.Name
+	goType := strings.TrimPrefix(f.Type, "*")
+	l, err := dg.literal(f.Default, goType)
+	if errors.Is(err, errUnsupportedDefault) {
+		fmt.Fprintf(b, "// Default value of property %q is not set as default values are not supported on %s values\n", fieldOriginalName(f), goType)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(f.Type, "*") {
+		fmt.Fprintf(b, "if %s == nil {\nd := %s\n%s = &d\n}\n", expr, dg.typedLiteral(l, goType), expr)
+		return nil
+	}
+	fmt.Fprintf(b, "if %s {\n%s = %s\n}\n", dg.zeroCondition(expr, goType), expr, l)
+	return nil
+}
+
+// typedLiteral makes sure that a literal of a scalar value has the given type
+// (for instance for assigning it to a variable)
+func (dg *defaultsGenerator) typedLiteral(l, goType string) string {
+	switch goType {
+	case "string", "int", "float64", "bool", "time.Time":
+		return l
+	}
+	if strings.HasPrefix(l, goType+"(") || strings.HasPrefix(l, goType+"{") {
+		return l
+	}
+	if _, ok := dg.enums[goType]; ok && !strings.HasPrefix(l, `"`) {
+		// Enum constant
+		return l
+	}
+	return fmt.Sprintf("%s(%s)", goType, l)
+}
+
+func (dg *defaultsGenerator) zeroCondition(expr, goType string) string {
+	if goType == "Range" || goType == "Version" {
+		return fmt.Sprintf("%s == (%s{})", expr, goType)
+	}
+	if _, ok := collectionElemType(goType); ok {
+		return expr + " == nil"
+	}
+	underlyingType := goType
+	if dt, ok := dg.dataTypes[goType]; ok {
+		if dt.UnderlyingType == "" {
+			dg.imports["reflect"] = true
+			return fmt.Sprintf("reflect.ValueOf(%s).IsZero()", expr)
+		}
+		underlyingType = dt.UnderlyingType
+	}
+	if _, ok := dg.enums[goType]; ok {
+		underlyingType = "string"
+	}
+	switch builtinUnderlyingType(underlyingType) {
+	case "string":
+		return expr + ` == ""`
+	case "int", "float64":
+		return expr + " == 0"
+	case "bool":
+		return "!" + expr
+	case "time.Time":
+		if goType == "time.Time" {
+			return expr + ".IsZero()"
+		}
+		return fmt.Sprintf("time.Time(%s).IsZero()", expr)
+	}
+	if _, ok := collectionElemType(builtinUnderlyingType(underlyingType)); ok {
+		return expr + " == nil"
+	}
+	dg.imports["reflect"] = true
+	return fmt.Sprintf("reflect.ValueOf(%s).IsZero()", expr)
+}
+
+// literal returns the Go literal of a TOSCA value for the given Go type
+func (dg *defaultsGenerator) literal(v interface{}, goType string) (string, error) {
+	switch goType {
+	case "Range":
+		return rangeLiteral(v)
+	case "Version":
+		return versionLiteral(v)
+	}
+	if elemType, ok := collectionElemType(goType); ok {
+		if strings.HasPrefix(goType, "[]") {
+			return dg.sliceLiteral(v, goType, elemType)
+		}
+		return dg.mapLiteral(v, goType, elemType)
+	}
+	if e, ok := dg.enums[goType]; ok {
+		for _, ev := range e.Values {
+			if ev.Value == fmt.Sprint(v) {
+				return ev.Name, nil
+			}
+		}
+		return "", fmt.Errorf("invalid default value %v: expecting one of the valid values of %s", v, goType)
+	}
+	if dt, ok := dg.dataTypes[goType]; ok {
+		if dt.UnderlyingType == "" {
+			return dg.structLiteral(v, dt)
+		}
+		l, err := dg.literal(v, dt.UnderlyingType)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s(%s)", goType, l), nil
+	}
+	switch goType {
+	case "string", "int", "float64", "bool":
+		l, err := literal(v, kindOf(goType))
+		if err != nil {
+			return "", fmt.Errorf("invalid default value: %w", err)
+		}
+		return l, nil
+	case "time.Time":
+		l, err := dg.timeLiteral(v)
+		if err != nil {
+			return "", fmt.Errorf("invalid default value: %w", err)
+		}
+		return l, nil
+	}
+	if isBuiltinType(goType) {
+		l, err := dg.literal(v, builtinUnderlyingType(goType))
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s(%s)", goType, l), nil
+	}
+	return "", errUnsupportedDefault
+}
+
+func (dg *defaultsGenerator) sliceLiteral(v interface{}, goType, elemType string) (string, error) {
+	values, ok := v.([]interface{})
+	if !ok {
+		return "", fmt.Errorf("invalid default value %v: expecting a list", v)
+	}
+	elems := make([]string, 0, len(values))
+	for _, e := range values {
+		l, err := dg.literal(e, elemType)
+		if err != nil {
+			return "", err
+		}
+		elems = append(elems, l)
+	}
+	return fmt.Sprintf("%s{%s}", goType, strings.Join(elems, ", ")), nil
+}
+
+func (dg *defaultsGenerator) mapLiteral(v interface{}, goType, elemType string) (string, error) {
+	values, ok := mapValues(v)
+	if !ok {
+		return "", fmt.Errorf("invalid default value %v: expecting a map", v)
+	}
+	keyType := strings.TrimSuffix(strings.TrimPrefix(goType, "map["), "]"+elemType)
+	keys := make([]interface{}, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+	elems := make([]string, 0, len(values))
+	for _, k := range keys {
+		kl, err := dg.literal(k, keyType)
+		if err != nil {
+			return "", err
+		}
+		l, err := dg.literal(values[k], elemType)
+		if err != nil {
+			return "", err
+		}
+		elems = append(elems, kl+": "+l)
+	}
+	return fmt.Sprintf("%s{%s}", goType, strings.Join(elems, ", ")), nil
+}
+
+// mapValues returns values of a YAML map, maps having non-string keys (like with a TOSCA key_schema)
+// are decoded as map[interface{}]interface{}
+func mapValues(v interface{}) (map[interface{}]interface{}, bool) {
+	switch m := v.(type) {
+	case map[interface{}]interface{}:
+		return m, true
+	case map[string]interface{}:
+		values := make(map[interface{}]interface{}, len(m))
+		for k, e := range m {
+			values[k] = e
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+// structLiteral returns the literal of a complex data type, values of properties inherited from parent
+// data types are set on the embedded parent struct
+func (dg *defaultsGenerator) structLiteral(v interface{}, dt model.DataType) (string, error) {
+	values, ok := v.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("invalid default value %v: expecting a map of %s properties", v, dt.FQDTN)
+	}
+	remaining := make(map[string]interface{}, len(values))
+	for k, e := range values {
+		remaining[k] = e
+	}
+	elems := make([]string, 0, len(values))
+	for _, f := range dt.Fields {
+		e, ok := remaining[f.OriginalName]
+		if !ok || f.Refinement {
+			// Values of refined properties are set on the embedded parent struct
+			continue
+		}
+		delete(remaining, f.OriginalName)
+		goType := strings.TrimPrefix(f.Type, "*")
+		l, err := dg.literal(e, goType)
+		if err != nil {
+			return "", err
+		}
+		if strings.HasPrefix(f.Type, "*") {
+			// Pointers to scalar values can't be expressed as literals
+			return "", errUnsupportedDefault
+		}
+		elems = append(elems, f.Name+": "+l)
+	}
+	if len(remaining) > 0 {
+		parent, ok := dg.dataTypes[dt.DerivedFrom]
+		if !ok || !isStructType(dt) {
+			keys := make([]string, 0, len(remaining))
+			for k := range remaining {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			return "", fmt.Errorf("invalid default value: unknown properties %v for %s", keys, dt.FQDTN)
+		}
+		l, err := dg.structLiteral(remaining, parent)
+		if err != nil {
+			return "", err
+		}
+		elems = append([]string{embeddedFieldName(dt.DerivedFrom) + ": " + l}, elems...)
+	}
+	return fmt.Sprintf("%s{%s}", dt.Name, strings.Join(elems, ", ")), nil
+}
+
+// timeLiteral returns the literal of a TOSCA timestamp value as a time.Time
+func (ft *fileTypes) timeLiteral(v interface{}) (string, error) {
+	t, ok := v.(time.Time)
+	if !ok {
+		ts, err := builtin.ParseTimestamp(fmt.Sprint(v))
+		if err != nil {
+			return "", err
+		}
+		t = ts.Time()
+	}
+	ft.imports["time"] = true
+	loc := "time.UTC"
+	if _, offset := t.Zone(); offset != 0 {
+		loc = fmt.Sprintf("time.FixedZone(\"\", %d)", offset)
+	}
+	return fmt.Sprintf("time.Date(%d, %d, %d, %d, %d, %d, %d, %s)", t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc), nil
+}
+
+// rangeLiteral returns the literal of a TOSCA range value
+func rangeLiteral(v interface{}) (string, error) {
+	bounds, ok := v.([]interface{})
+	if !ok || len(bounds) != 2 {
+		return "", fmt.Errorf("invalid default value %v: expecting a range as a list of two values", v)
+	}
+	lower, err := literal(bounds[0], intKind)
+	if err != nil {
+		return "", fmt.Errorf("invalid default value: %w", err)
+	}
+	if strings.HasPrefix(lower, "-") {
+		return "", fmt.Errorf("invalid default value %v: range bounds can't be negative", v)
+	}
+	if s, ok := bounds[1].(string); ok && strings.EqualFold(s, "UNBOUNDED") {
+		return fmt.Sprintf("Range{LowerBound: %s, Unbounded: true}", lower), nil
+	}
+	upper, err := literal(bounds[1], intKind)
+	if err != nil {
+		return "", fmt.Errorf("invalid default value: %w", err)
+	}
+	if strings.HasPrefix(upper, "-") {
+		return "", fmt.Errorf("invalid default value %v: range bounds can't be negative", v)
+	}
+	return fmt.Sprintf("Range{LowerBound: %s, UpperBound: %s}", lower, upper), nil
+}
+
+// versionLiteral returns the literal of a TOSCA version value
+//
+// Versions like 1.0 are decoded from YAML as floats, so they are converted back to a major.minor form.
+func versionLiteral(v interface{}) (string, error) {
+	s := fmt.Sprint(v)
+	switch n := v.(type) {
+	case int:
+		s = strconv.Itoa(n) + ".0"
+	case float64:
+		s = strconv.FormatFloat(n, 'f', -1, 64)
+		if !strings.Contains(s, ".") {
+			s += ".0"
+		}
+	}
+	version, err := builtin.ParseVersion(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid default value: %w", err)
+	}
+	elems := make([]string, 0, 5)
+	for _, e := range []struct {
+		name  string
+		value uint64
+	}{{"Major", version.Major}, {"Minor", version.Minor}, {"Fix", version.Fix}} {
+		if e.value != 0 {
+			elems = append(elems, fmt.Sprintf("%s: %d", e.name, e.value))
+		}
+	}
+	if version.Qualifier != "" {
+		elems = append(elems, fmt.Sprintf("Qualifier: %q", version.Qualifier))
+	}
+	if version.Build != 0 {
+		elems = append(elems, fmt.Sprintf("Build: %d", version.Build))
+	}
+	return fmt.Sprintf("Version{%s}", strings.Join(elems, ", ")), nil
+}
+
+// isBuiltinType returns true for TOSCA builtin types that are generated with the GenerateBuiltinTypes option
+func isBuiltinType(goType string) bool {
+	return goType == "Range" || goType == "Version" || builtinUnderlyingType(goType) != goType
+}
+
+// builtinUnderlyingType returns the Go type underlying TOSCA builtin types deriving from a Go builtin type
+// or the given type otherwise
+func builtinUnderlyingType(goType string) string {
+	switch goType {
+	case "ScalarUnit", "ScalarUnitSize", "ScalarUnitTime", "ScalarUnitFrequency", "ScalarUnitBitRate":
+		return "string"
+	case "Timestamp":
+		return "time.Time"
+	}
+	return goType
+}
@@ -12,11 +12,5 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package tosca
-
-// An EntrySchema is the representation of a TOSCA Entry Schema
-type EntrySchema struct {
-	Type        string `yaml:"type" json:"type"`
-	Description string `yaml:"description,omitempty" json:"description,omitempty"`
-	//Constraints []ConstraintClause `yaml:"constraints,omitempty"`
-}
+// Package generator generates formatted Go source files from the model package representation.
+package generator
@@ -0,0 +1,169 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+
+	"github.com/ystia/tdt2go/pkg/model"
+)
+
+// generatedHeader is the first line of Go source files generated by tdt2go
+const generatedHeader = "// Code generated by tdt2go"
+
+// IsGeneratedFile returns true if the given Go source file content has been generated by tdt2go
+func IsGeneratedFile(content []byte) bool {
+	return bytes.HasPrefix(content, []byte(generatedHeader+"\n"))
+}
+
+// GenerateFiles generates formatted Go source files of a same package based on the given model.File
+// representation and returns their contents indexed by file name.
+//
+// Data types are dispatched into files named by fileName, enums are generated into the file of the first
+// data type using them. Generated methods are computed knowing all data types of f whatever their file and
+// each file only imports packages it uses.
+func (g *Generator) GenerateFiles(f model.File, fileName func(dt model.DataType) string) (map[string][]byte, error) {
+	t, f, err := g.fileTemplate(f)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]*model.File)
+	names := make([]string, 0)
+	typesFiles := make(map[string]string, len(f.DataTypes))
+	for _, dt := range f.DataTypes {
+		name := fileName(dt)
+		file, ok := files[name]
+		if !ok {
+			file = &model.File{Package: f.Package, Imports: f.Imports}
+			files[name] = file
+			names = append(names, name)
+		}
+		file.DataTypes = append(file.DataTypes, dt)
+		typesFiles[dt.Name] = name
+	}
+	for _, e := range f.Enums {
+		name, err := enumFileName(e, f.DataTypes, typesFiles)
+		if err != nil {
+			return nil, err
+		}
+		files[name].Enums = append(files[name].Enums, e)
+	}
+
+	result := make(map[string][]byte, len(files))
+	for _, name := range names {
+		content, err := executeTemplate(t, *files[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate file %q: %w", name, err)
+		}
+		content, err = removeUnusedImports(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate file %q: %w", name, err)
+		}
+		result[name] = content
+	}
+	return result, nil
+}
+
+// enumFileName returns the file name of the first data type having a field using the given enum
+func enumFileName(e model.Enum, dataTypes []model.DataType, typesFiles map[string]string) (string, error) {
+	for _, dt := range dataTypes {
+		for _, f := range dt.Fields {
+			used, err := usesType(f.Type, e.Name)
+			if err != nil {
+				return "", fmt.Errorf("invalid type %q of field %q of %q: %w", f.Type, f.Name, dt.Name, err)
+			}
+			if used {
+				return typesFiles[dt.Name], nil
+			}
+		}
+	}
+	return "", fmt.Errorf("enum %q is not used by any data type", e.Name)
+}
+
+// usesType returns true if the Go type expression goType refers to the type named typeName
+func usesType(goType, typeName string) (bool, error) {
+	expr, err := parser.ParseExpr(goType)
+	if err != nil {
+		return false, err
+	}
+	used := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && id.Name == typeName {
+			used = true
+		}
+		return !used
+	})
+	return used, nil
+}
+
+// removeUnusedImports removes imports of packages that are not referenced in the given Go source file
+func removeUnusedImports(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse generated file: %w", err)
+	}
+	used := make(map[string]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok && id.Obj == nil {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+	unused := make([]string, 0)
+	for _, spec := range f.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse generated file: %w", err)
+		}
+		if !used[importName(importPath)] {
+			unused = append(unused, importPath)
+		}
+	}
+	removed := false
+	for _, importPath := range unused {
+		removed = astutil.DeleteImport(fset, f, importPath) || removed
+	}
+	if !removed {
+		return src, nil
+	}
+	b := &bytes.Buffer{}
+	err = format.Node(b, fset, f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated file: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// importName returns the package name of an import path, omitting version suffixes like in gopkg.in/yaml.v3
+func importName(importPath string) string {
+	name := path.Base(importPath)
+	if i := strings.Index(name, "."); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
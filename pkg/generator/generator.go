@@ -0,0 +1,158 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/ystia/tdt2go/pkg/model"
+)
+
+// Generator is the generator used to convert model.DataTypes into Go source file
+type Generator struct {
+	// GenerateValidation allows to generate on each data type a Validate method that enforces TOSCA constraints
+	GenerateValidation bool
+	// GenerateDefaults allows to generate on each complex data type a SetDefaults method and a constructor
+	// that apply TOSCA default values
+	GenerateDefaults bool
+	// GenerateParentMethods allows to generate on flattened structs a Parent method converting values into
+	// their parent type
+	GenerateParentMethods bool
+}
+
+// GenerateFile generates a formatted Go source file based on the given model.File representation
+func (g *Generator) GenerateFile(f model.File) ([]byte, error) {
+	t, f, err := g.fileTemplate(f)
+	if err != nil {
+		return nil, err
+	}
+	return executeTemplate(t, f)
+}
+
+// fileTemplate returns the template generating files of types of f, generated methods are computed
+// knowing all types of f. It returns f with imports required by generated methods.
+func (g *Generator) fileTemplate(f model.File) (*template.Template, model.File, error) {
+	ft := newFileTypes(f)
+	validateMethods := make(map[string]string)
+	defaultsMethods := make(map[string]string)
+	builtinMethods := make(map[string]string)
+	parentMethods := make(map[string]string)
+	for _, dt := range f.DataTypes {
+		m, err := ft.builtinMethods(dt)
+		if err != nil {
+			return nil, f, fmt.Errorf("failed to generate builtin types code: %w", err)
+		}
+		builtinMethods[dt.Name] = m
+		if g.GenerateParentMethods {
+			m, err := ft.parentMethod(dt)
+			if err != nil {
+				return nil, f, err
+			}
+			parentMethods[dt.Name] = m
+		}
+		if g.GenerateValidation {
+			m, err := (&validationGenerator{fileTypes: ft}).validateMethod(dt)
+			if err != nil {
+				return nil, f, fmt.Errorf("failed to generate validation code: %w", err)
+			}
+			validateMethods[dt.Name] = m
+		}
+		if g.GenerateDefaults {
+			m, err := (&defaultsGenerator{ft}).defaultsMethods(dt)
+			if err != nil {
+				return nil, f, fmt.Errorf("failed to generate default values code: %w", err)
+			}
+			defaultsMethods[dt.Name] = m
+		}
+	}
+	f.Imports = mergeImports(f.Imports, ft.imports)
+
+	t := template.New("generator")
+	t.Funcs(template.FuncMap{
+		"asComment":      asComment,
+		"representation": representation,
+		"join":           strings.Join,
+		"validateMethod": func(dt model.DataType) string {
+			return validateMethods[dt.Name]
+		},
+		"defaultsMethods": func(dt model.DataType) string {
+			return defaultsMethods[dt.Name]
+		},
+		"builtinMethods": func(dt model.DataType) string {
+			return builtinMethods[dt.Name]
+		},
+		"parentMethod": func(dt model.DataType) string {
+			return parentMethods[dt.Name]
+		},
+	})
+	return template.Must(t.Parse(fileTemplate)), f, nil
+}
+
+func executeTemplate(t *template.Template, f model.File) ([]byte, error) {
+	b := &bytes.Buffer{}
+	err := t.Execute(b, f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate file, templating failed: %w", err)
+	}
+
+	result, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated file: %w", err)
+	}
+
+	return result, nil
+}
+
+func mergeImports(imports []string, additional map[string]bool) []string {
+	result := make([]string, 0, len(imports)+len(additional))
+	for _, i := range imports {
+		if !additional[i] {
+			result = append(result, i)
+		}
+	}
+	for i := range additional {
+		result = append(result, i)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// representation describes the TOSCA definition a type is generated from
+func representation(dt model.DataType) string {
+	switch dt.Kind {
+	case model.NodeTypePropertiesKind:
+		return fmt.Sprintf("properties of %s node type", dt.FQDTN)
+	case model.NodeTypeAttributesKind:
+		return fmt.Sprintf("attributes of %s node type", dt.FQDTN)
+	case model.CapabilityTypePropertiesKind:
+		return fmt.Sprintf("properties of %s capability type", dt.FQDTN)
+	case model.RelationshipTypePropertiesKind:
+		return fmt.Sprintf("properties of %s relationship type", dt.FQDTN)
+	case model.InterfaceTypeOperationInputsKind:
+		return fmt.Sprintf("inputs of %s operation of %s interface type", dt.Operation, dt.FQDTN)
+	case model.NodeTypeOperationInputsKind:
+		return fmt.Sprintf("inputs of %s operation of %s node type", dt.Operation, dt.FQDTN)
+	}
+	return fmt.Sprintf("%s data type", dt.FQDTN)
+}
+
+func asComment(input string) string {
+	return strings.ReplaceAll(input, "\n", "\n// ")
+}
@@ -0,0 +1,509 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ystia/tdt2go/pkg/model"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+)
+
+func TestGenerator_GenerateFile(t *testing.T) {
+	type args struct {
+		f model.File
+	}
+	tests := []struct {
+		name    string
+		g       *Generator
+		args    args
+		wantErr bool
+	}{
+		{"EmptyFile", &Generator{}, args{model.File{Package: "something"}}, false},
+		{"SimpleDataType", &Generator{}, args{
+			model.File{
+				Package: "simple",
+				DataTypes: []model.DataType{
+					{
+						Name:  "MyDT",
+						FQDTN: "org.ystia.datatypes.MyDT",
+						Fields: []model.Field{
+							{Name: "F1", Type: "string"},
+							{Name: "F2", Type: "int"},
+						},
+					},
+				},
+			},
+		}, false},
+		{"FieldsTags", &Generator{}, args{
+			model.File{
+				Package: "simple",
+				DataTypes: []model.DataType{
+					{
+						Name:  "MyDT",
+						FQDTN: "org.ystia.datatypes.MyDT",
+						Fields: []model.Field{
+							{Name: "F1", OriginalName: "f1", Type: "string"},
+							{Name: "F2", OriginalName: "my_f2", Type: "int"},
+						},
+					},
+				},
+			},
+		}, false},
+		{"WithImports", &Generator{}, args{
+			model.File{
+				Package: "simple",
+				Imports: []string{"fmt", "time"},
+				DataTypes: []model.DataType{
+					{
+						Name:  "MyDT",
+						FQDTN: "org.ystia.datatypes.MyDT",
+						Fields: []model.Field{
+							{Name: "F1", OriginalName: "f1", Type: "string"},
+							{Name: "F2", OriginalName: "my_f2", Type: "time.Date"},
+						},
+					},
+				},
+			},
+		}, false},
+		{"DerivedDataType", &Generator{}, args{
+			model.File{
+				Package: "simple",
+				DataTypes: []model.DataType{
+					{
+						Name:  "MyDT",
+						FQDTN: "org.ystia.datatypes.MyDT",
+						Fields: []model.Field{
+							{Name: "F1", Type: "string"},
+							{Name: "F2", Type: "int"},
+						},
+					},
+					{
+						Name:        "MyDerivedDT",
+						FQDTN:       "org.ystia.datatypes.MyDerivedDT",
+						DerivedFrom: "MyDT",
+						Fields: []model.Field{
+							{Name: "F3", Type: "[]string"},
+						},
+					},
+				},
+			},
+		}, false},
+		{"DerivedFromBuildtin", &Generator{}, args{
+			model.File{
+				Package: "simple",
+				DataTypes: []model.DataType{
+					{
+						Name:        "MyDerivedDT",
+						FQDTN:       "org.ystia.datatypes.MyDerivedDT",
+						DerivedFrom: "string",
+					},
+				},
+			},
+		}, false},
+		{"WithDescriptions", &Generator{}, args{
+			model.File{
+				Package: "simple",
+				DataTypes: []model.DataType{
+					{
+						Name:        "MyDT",
+						FQDTN:       "org.ystia.datatypes.MyDT",
+						Description: "A oneliner description",
+						Fields: []model.Field{
+							{Name: "F1", Type: "string"},
+							{Name: "F2", Type: "int", Description: "A multiline\ndescription"},
+						},
+					},
+					{
+						Name:        "MyDerivedDT",
+						FQDTN:       "org.ystia.datatypes.MyDerivedDT",
+						DerivedFrom: "MyDT",
+						Description: "A multiline\ndescription",
+						Fields: []model.Field{
+							{Name: "F3", Type: "[]string", Description: "A oneliner description"},
+						},
+					},
+				},
+			},
+		}, false},
+		{"Validation", &Generator{GenerateValidation: true}, args{
+			model.File{
+				Package: "simple",
+				DataTypes: []model.DataType{
+					{
+						Name:  "Root",
+						FQDTN: "org.ystia.datatypes.Root",
+					},
+					{
+						Name:           "PortDef",
+						FQDTN:          "org.ystia.datatypes.PortDef",
+						DerivedFrom:    "int",
+						UnderlyingType: "int",
+						Constraints: []model.Constraint{
+							{Operator: "in_range", Values: []interface{}{1, 65535}},
+						},
+					},
+					{
+						Name:           "UserPortDef",
+						FQDTN:          "org.ystia.datatypes.UserPortDef",
+						DerivedFrom:    "PortDef",
+						UnderlyingType: "int",
+						Constraints: []model.Constraint{
+							{Operator: "greater_or_equal", Values: []interface{}{1024}},
+						},
+					},
+					{
+						Name:        "PortSpec",
+						FQDTN:       "org.ystia.datatypes.PortSpec",
+						DerivedFrom: "Root",
+						Fields: []model.Field{
+							{Name: "Protocol", OriginalName: "protocol", Type: "string", Constraints: []model.Constraint{
+								{Operator: "valid_values", Values: []interface{}{"udp", "tcp", "igmp"}},
+							}},
+							{Name: "Source", OriginalName: "source", Type: "PortDef", UnderlyingType: "int", Constraints: []model.Constraint{
+								{Operator: "less_than", Values: []interface{}{60000}},
+							}},
+							{Name: "Target", OriginalName: "target", Type: "UserPortDef", UnderlyingType: "int"},
+							{Name: "TargetRange", OriginalName: "target_range", Type: "Range", Constraints: []model.Constraint{
+								{Operator: "in_range", Values: []interface{}{1, 65535}},
+							}},
+						},
+					},
+					{
+						Name:  "Everything",
+						FQDTN: "org.ystia.datatypes.Everything",
+						Fields: []model.Field{
+							{Name: "Name", OriginalName: "name", Type: "string", Constraints: []model.Constraint{
+								{Operator: "min_length", Values: []interface{}{2}},
+								{Operator: "max_length", Values: []interface{}{20}},
+								{Operator: "pattern", Values: []interface{}{"[a-z]+(-[a-z]+)*"}},
+							}},
+							{Name: "Code", OriginalName: "code", Type: "string", Constraints: []model.Constraint{
+								{Operator: "length", Values: []interface{}{4}},
+								{Operator: "greater_than", Values: []interface{}{"AAAA"}},
+							}},
+							{Name: "Ratio", OriginalName: "ratio", Type: "float64", Constraints: []model.Constraint{
+								{Operator: "in_range", Values: []interface{}{0, 0.5}},
+							}},
+							{Name: "Count", OriginalName: "count", Type: "int", Constraints: []model.Constraint{
+								{Operator: "in_range", Values: []interface{}{10, "UNBOUNDED"}},
+								{Operator: "less_or_equal", Values: []interface{}{1000.0}},
+							}},
+							{Name: "Enabled", OriginalName: "enabled", Type: "bool", Constraints: []model.Constraint{
+								{Operator: "equal", Values: []interface{}{true}},
+							}},
+							{Name: "Tags", OriginalName: "tags", Type: "[]string", Constraints: []model.Constraint{
+								{Operator: "max_length", Values: []interface{}{3}},
+							}},
+							{Name: "Ports", OriginalName: "ports", Type: "map[string][]PortSpec"},
+							{Name: "Others", OriginalName: "others", Type: "[]Other"},
+							{Name: "Spec", OriginalName: "spec", Type: "PortSpec"},
+						},
+					},
+				},
+			},
+		}, false},
+		{"ValidationInvalidPattern", &Generator{GenerateValidation: true}, args{
+			model.File{
+				Package: "simple",
+				DataTypes: []model.DataType{
+					{
+						Name:  "MyDT",
+						FQDTN: "org.ystia.datatypes.MyDT",
+						Fields: []model.Field{
+							{Name: "F1", OriginalName: "f1", Type: "string", Constraints: []model.Constraint{
+								{Operator: "pattern", Values: []interface{}{"x{2,1}"}},
+							}},
+						},
+					},
+				},
+			},
+		}, true},
+		{"ValidationInvalidValue", &Generator{GenerateValidation: true}, args{
+			model.File{
+				Package: "simple",
+				DataTypes: []model.DataType{
+					{
+						Name:  "MyDT",
+						FQDTN: "org.ystia.datatypes.MyDT",
+						Fields: []model.Field{
+							{Name: "F1", OriginalName: "f1", Type: "int", Constraints: []model.Constraint{
+								{Operator: "equal", Values: []interface{}{"one"}},
+							}},
+						},
+					},
+				},
+			},
+		}, true},
+		{"ValidationUnsupportedConstraint", &Generator{GenerateValidation: true}, args{
+			model.File{
+				Package: "simple",
+				DataTypes: []model.DataType{
+					{
+						Name:  "MyDT",
+						FQDTN: "org.ystia.datatypes.MyDT",
+						Fields: []model.Field{
+							{Name: "F1", OriginalName: "f1", Type: "bool", Constraints: []model.Constraint{
+								{Operator: "pattern", Values: []interface{}{"true"}},
+							}},
+						},
+					},
+				},
+			},
+		}, true},
+		{"ValidationInvalidTimestamp", &Generator{GenerateValidation: true}, args{
+			model.File{
+				Package: "simple",
+				DataTypes: []model.DataType{
+					{
+						Name:  "MyDT",
+						FQDTN: "org.ystia.datatypes.MyDT",
+						Fields: []model.Field{
+							{Name: "F1", OriginalName: "f1", Type: "time.Time", Constraints: []model.Constraint{
+								{Operator: "greater_than", Values: []interface{}{"yesterday"}},
+							}},
+						},
+					},
+				},
+			},
+		}, true},
+		{"Enums", &Generator{GenerateValidation: true}, args{
+			model.File{
+				Package: "simple",
+				DataTypes: []model.DataType{
+					{
+						Name:  "PortSpec",
+						FQDTN: "org.ystia.datatypes.PortSpec",
+						Fields: []model.Field{
+							{Name: "Protocol", OriginalName: "protocol", Type: "ProtocolType", UnderlyingType: "string", Constraints: []model.Constraint{
+								{Operator: "valid_values", Values: []interface{}{"udp", "tcp"}},
+							}},
+						},
+					},
+				},
+				Enums: []model.Enum{
+					{
+						Name:       "ProtocolType",
+						Properties: []string{"org.ystia.datatypes.PortSpec.protocol", "org.ystia.datatypes.Other.protocol"},
+						Values: []model.EnumValue{
+							{Name: "ProtocolUDP", Value: "udp"},
+							{Name: "ProtocolTCP", Value: "tcp"},
+						},
+					},
+				},
+			},
+		}, false},
+		{"RequiredAndPointers", &Generator{GenerateValidation: true}, args{
+			model.File{
+				Package: "simple",
+				DataTypes: []model.DataType{
+					{
+						Name:           "PortDef",
+						FQDTN:          "org.ystia.datatypes.PortDef",
+						DerivedFrom:    "int",
+						UnderlyingType: "int",
+					},
+					{
+						Name:  "MyDT",
+						FQDTN: "org.ystia.datatypes.MyDT",
+						Fields: []model.Field{
+							{Name: "F1", OriginalName: "f1", Type: "string", Required: true, Constraints: []model.Constraint{
+								{Operator: "min_length", Values: []interface{}{1}},
+							}},
+							{Name: "F2", OriginalName: "f2", Type: "*int", Constraints: []model.Constraint{
+								{Operator: "greater_than", Values: []interface{}{0}},
+							}},
+							{Name: "F3", OriginalName: "f3", Type: "*PortDef", UnderlyingType: "int", Constraints: []model.Constraint{
+								{Operator: "less_or_equal", Values: []interface{}{1024}},
+							}},
+							{Name: "F4", OriginalName: "f4", Type: "*Version"},
+							{Name: "F5", OriginalName: "f5", Type: "float64", Constraints: []model.Constraint{
+								{Operator: "greater_than", Values: []interface{}{0}},
+							}},
+						},
+					},
+				},
+			},
+		}, false},
+		{"ParentMethods", &Generator{GenerateParentMethods: true}, args{
+			model.File{
+				Package: "simple",
+				DataTypes: []model.DataType{
+					{Name: "Port", FQDTN: "org.ystia.datatypes.Port", DerivedFrom: "int", UnderlyingType: "int"},
+					{Name: "UserPort", FQDTN: "org.ystia.datatypes.UserPort", DerivedFrom: "Port", UnderlyingType: "int"},
+					{
+						Name:  "Endpoint",
+						FQDTN: "org.ystia.datatypes.Endpoint",
+						Fields: []model.Field{
+							{Name: "Port", OriginalName: "port", Type: "Port", UnderlyingType: "int"},
+						},
+					},
+					{
+						Name:          "UserEndpoint",
+						FQDTN:         "org.ystia.datatypes.UserEndpoint",
+						FlattenedFrom: "Endpoint",
+						Fields: []model.Field{
+							{Name: "Port", OriginalName: "port", Type: "UserPort", UnderlyingType: "int", Inherited: true},
+						},
+					},
+				},
+			},
+		}, false},
+		{"ParentMethodsUnconvertibleRefinement", &Generator{GenerateParentMethods: true}, args{
+			model.File{
+				Package: "simple",
+				DataTypes: []model.DataType{
+					{Name: "Port", FQDTN: "org.ystia.datatypes.Port", DerivedFrom: "int", UnderlyingType: "int"},
+					{Name: "UserPort", FQDTN: "org.ystia.datatypes.UserPort", DerivedFrom: "Port", UnderlyingType: "int"},
+					{
+						Name:  "Endpoint",
+						FQDTN: "org.ystia.datatypes.Endpoint",
+						Fields: []model.Field{
+							{Name: "Ports", OriginalName: "ports", Type: "[]Port"},
+						},
+					},
+					{
+						Name:          "UserEndpoint",
+						FQDTN:         "org.ystia.datatypes.UserEndpoint",
+						FlattenedFrom: "Endpoint",
+						Fields: []model.Field{
+							{Name: "Ports", OriginalName: "ports", Type: "[]UserPort", Inherited: true},
+						},
+					},
+				},
+			},
+		}, true},
+		{"Defaults", &Generator{GenerateDefaults: true}, args{
+			model.File{
+				Package: "simple",
+				DataTypes: []model.DataType{
+					{
+						Name:           "PortDef",
+						FQDTN:          "org.ystia.datatypes.PortDef",
+						DerivedFrom:    "int",
+						UnderlyingType: "int",
+					},
+					{
+						Name:  "Root",
+						FQDTN: "tosca.datatypes.Root",
+					},
+					{
+						Name:        "Endpoint",
+						FQDTN:       "org.ystia.datatypes.Endpoint",
+						DerivedFrom: "Root",
+						Fields: []model.Field{
+							{Name: "Host", OriginalName: "host", Type: "string", Default: "localhost"},
+							{Name: "Port", OriginalName: "port", Type: "PortDef", UnderlyingType: "int", Default: 8080},
+							{Name: "Protocol", OriginalName: "protocol", Type: "ProtocolType", UnderlyingType: "string", Default: "tcp"},
+						},
+					},
+					{
+						Name:        "Server",
+						FQDTN:       "org.ystia.datatypes.Server",
+						DerivedFrom: "Endpoint",
+						Fields: []model.Field{
+							{Name: "Enabled", OriginalName: "enabled", Type: "*bool", Default: true},
+							{Name: "Ratio", OriginalName: "ratio", Type: "float64", Default: 0.5},
+							{Name: "Since", OriginalName: "since", Type: "time.Time", Default: "2001-12-14 21:59:43.10 -5"},
+							{Name: "Tags", OriginalName: "tags", Type: "[]string", Default: []interface{}{"a", "b"}},
+							{Name: "Limits", OriginalName: "limits", Type: "map[string]int", Default: map[string]interface{}{"max": 10, "min": 1}},
+							{Name: "Backup", OriginalName: "backup", Type: "Endpoint", Default: map[string]interface{}{"host": "backup", "port": 8081}},
+							{Name: "Primary", OriginalName: "primary", Type: "Endpoint"},
+							{Name: "Version", OriginalName: "version", Type: "Version", Default: "1.0.0"},
+							{Name: "MinVersion", OriginalName: "min_version", Type: "*Version", Default: 2.5},
+							{Name: "Ports", OriginalName: "ports", Type: "Range", Default: []interface{}{1024, "UNBOUNDED"}},
+							{Name: "Retries", OriginalName: "retries", Type: "*Range", Default: []interface{}{1, 3}},
+							{Name: "Custom", OriginalName: "custom", Type: "Other", Default: "value"},
+						},
+					},
+				},
+				Enums: []model.Enum{
+					{Name: "ProtocolType", Values: []model.EnumValue{{Name: "ProtocolTCP", Value: "tcp"}, {Name: "ProtocolUDP", Value: "udp"}}},
+				},
+			},
+		}, false},
+		{"DefaultsInvalidValue", &Generator{GenerateDefaults: true}, args{
+			model.File{
+				Package: "simple",
+				DataTypes: []model.DataType{
+					{
+						Name:  "MyDT",
+						FQDTN: "org.ystia.datatypes.MyDT",
+						Fields: []model.Field{
+							{Name: "F1", OriginalName: "f1", Type: "int", Default: "not an int"},
+						},
+					},
+				},
+			},
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.g.GenerateFile(tt.args.f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Generator.GenerateFile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil {
+				assert.Assert(t, golden.String(string(got), "golden/"+tt.name))
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateFiles(t *testing.T) {
+	f := model.File{
+		Package: "split",
+		Imports: []string{"time"},
+		DataTypes: []model.DataType{
+			{
+				Name:  "Base",
+				FQDTN: "org.ystia.datatypes.Base",
+				Fields: []model.Field{
+					{Name: "Name", OriginalName: "name", Type: "string", Constraints: []model.Constraint{
+						{Operator: "pattern", Values: []interface{}{"^[a-z]+$"}},
+					}},
+				},
+			},
+			{
+				Name:        "Child",
+				FQDTN:       "org.ystia.datatypes.Child",
+				DerivedFrom: "Base",
+				Fields: []model.Field{
+					{Name: "Created", OriginalName: "created", Type: "time.Time"},
+					{Name: "Protocols", OriginalName: "protocols", Type: "[]ProtocolType"},
+				},
+			},
+		},
+		Enums: []model.Enum{
+			{Name: "ProtocolType", Properties: []string{"org.ystia.datatypes.Child.protocols"}, Values: []model.EnumValue{
+				{Name: "ProtocolTypeTCP", Value: "tcp"},
+				{Name: "ProtocolTypeUDP", Value: "udp"},
+			}},
+		},
+	}
+	g := &Generator{GenerateValidation: true}
+	files, err := g.GenerateFiles(f, func(dt model.DataType) string {
+		return strings.ToLower(dt.Name) + ".go"
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(files), 2)
+	for _, name := range []string{"base.go", "child.go"} {
+		assert.Assert(t, IsGeneratedFile(files[name]), "%s is not a generated file", name)
+		assert.Assert(t, golden.String(string(files[name]), "golden/GenerateFiles_"+strings.TrimSuffix(name, ".go")))
+	}
+}
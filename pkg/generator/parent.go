@@ -0,0 +1,113 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ystia/tdt2go/pkg/model"
+)
+
+// parentMethod generates on flattened structs a Parent method converting values into their parent type.
+//
+// Inherited fields refined into a type deriving from the parent field type are converted using the Parent
+// method of flattened types, the embedded parent struct of other structs or a type conversion for types
+// deriving from primitive types. An error is returned for other types as their values could not be converted.
+func (ft *fileTypes) parentMethod(dt model.DataType) (string, error) {
+	if dt.FlattenedFrom == "" {
+		return "", nil
+	}
+	inherited := make([]model.Field, 0, len(dt.Fields))
+	for _, f := range dt.Fields {
+		if f.Inherited {
+			inherited = append(inherited, f)
+		}
+	}
+	l, err := ft.parentLiteral(dt.FlattenedFrom, inherited)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate Parent method of %s: %w", dt.Name, err)
+	}
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "// Parent returns v converted into its %s parent type\n", dt.FlattenedFrom)
+	fmt.Fprintf(b, "func (v %s) Parent() %s {\n", dt.Name, dt.FlattenedFrom)
+	fmt.Fprintf(b, "return %s\n}", l)
+	return b.String(), nil
+}
+
+// parentLiteral returns the literal of a parent struct built from the inherited fields of a flattened struct,
+// values of properties inherited by the parent from its own parents are set on its embedded struct.
+//
+// Fields of parent types that are not generated in the file are expected to be of the same type.
+func (ft *fileTypes) parentLiteral(parent string, inherited []model.Field) (string, error) {
+	dt, ok := ft.dataTypes[parent]
+	if !ok {
+		elems := make([]string, 0, len(inherited))
+		for _, f := range inherited {
+			elems = append(elems, fmt.Sprintf("\n%s: v.%s,", f.Name, f.Name))
+		}
+		if len(elems) == 0 {
+			return parent + "{}", nil
+		}
+		return fmt.Sprintf("%s{%s\n}", parent, strings.Join(elems, "")), nil
+	}
+	values := make(map[string]model.Field, len(inherited))
+	for _, f := range inherited {
+		values[f.OriginalName] = f
+	}
+	elems := make([]string, 0, len(dt.Fields)+1)
+	if dt.DerivedFrom != "" && isStructType(dt) {
+		l, err := ft.parentLiteral(dt.DerivedFrom, inherited)
+		if err != nil {
+			return "", err
+		}
+		elems = append(elems, fmt.Sprintf("\n%s: %s,", embeddedFieldName(dt.DerivedFrom), l))
+	}
+	for _, f := range dt.Fields {
+		v, ok := values[f.OriginalName]
+		if !ok || f.Refinement {
+			continue
+		}
+		expr, err := ft.convertValue("v."+v.Name, v.Type, f.Type)
+		if err != nil {
+			return "", fmt.Errorf("property %q: %w", fieldOriginalName(f), err)
+		}
+		elems = append(elems, fmt.Sprintf("\n%s: %s,", f.Name, expr))
+	}
+	if len(elems) == 0 {
+		return dt.Name + "{}", nil
+	}
+	return fmt.Sprintf("%s{%s\n}", dt.Name, strings.Join(elems, "")), nil
+}
+
+// convertValue returns the expression converting a value into a type it derives from
+func (ft *fileTypes) convertValue(expr, from, to string) (string, error) {
+	for t := from; t != to; {
+		dt, ok := ft.dataTypes[t]
+		switch {
+		case !ok:
+			return "", fmt.Errorf("%s values could not be converted into %s", from, to)
+		case dt.FlattenedFrom != "":
+			expr, t = expr+".Parent()", dt.FlattenedFrom
+		case dt.DerivedFrom != "" && isStructType(dt):
+			expr, t = expr+"."+embeddedFieldName(dt.DerivedFrom), dt.DerivedFrom
+		case dt.DerivedFrom != "":
+			expr, t = fmt.Sprintf("%s(%s)", dt.DerivedFrom, expr), dt.DerivedFrom
+		default:
+			return "", fmt.Errorf("%s values could not be converted into %s", from, to)
+		}
+	}
+	return expr, nil
+}
@@ -27,16 +27,53 @@ import (
 ){{ end }}
 {{- range .DataTypes}}
 
-// {{.Name}} is the generated representation of {{.FQDTN}} data type{{ if .Description }}
+// {{.Name}} is the generated representation of {{ representation . }}{{ if .Description }}
 //
 // {{ asComment .Description }}{{end}}
 type {{.Name}} {{ if and (ne .DerivedFrom "") (eq (len .Fields) 0) }}{{.DerivedFrom}}{{ else }}struct {
 {{- if ne .DerivedFrom ""}}
 	{{.DerivedFrom}}
 {{- end}}
-	{{- range .Fields}}{{ if .Description }}
+	{{- range .Fields}}{{ if not .Refinement }}{{ if .Description }}
 	// {{ asComment .Description }}{{end}}
-	{{.Name}} {{.Type}} {{- if ne .OriginalName ""}} {{ $tick }}mapstructure:"{{.OriginalName}}" json:"{{.OriginalName}},omitempty"{{ $tick }}{{end}}{{end}}
+	{{.Name}} {{.Type}} {{- if ne .OriginalName ""}} {{ $tick }}mapstructure:"{{.OriginalName}}" json:"{{.OriginalName}}{{ if not .Required }},omitempty{{ end }}"{{ $tick }}{{end}}{{end}}{{end}}
 }
-{{end}}{{end -}}
+{{end}}
+{{- with validateMethod . }}
+
+{{ . }}
+{{- end}}
+{{- with defaultsMethods . }}
+
+{{ . }}
+{{- end}}
+{{- with builtinMethods . }}
+
+{{ . }}
+{{- end}}
+{{- with parentMethod . }}
+
+{{ . }}
+{{- end}}{{end}}
+{{- range $enum := .Enums}}
+
+// {{.Name}} is the generated representation of valid values of {{ join .Properties ", " }}
+type {{.Name}} string
+
+// Valid values of {{.Name}}
+const (
+{{- range .Values}}
+	{{.Name}} {{$enum.Name}} = {{ printf "%q" .Value }}
+{{- end}}
+)
+
+// IsValid returns true if v is one of the valid values of {{.Name}}
+func (v {{.Name}}) IsValid() bool {
+	switch v {
+	case {{ range $i, $v := .Values }}{{ if $i }}, {{ end }}{{ $v.Name }}{{ end }}:
+		return true
+	}
+	return false
+}
+{{end -}}
 `
@@ -0,0 +1,74 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/ystia/tdt2go/pkg/model"
+)
+
+// fileTypes indexes types generated in a file and collects imports required by generated methods
+type fileTypes struct {
+	// dataTypes are data types generated in the file indexed by their Go name
+	dataTypes map[string]model.DataType
+	// enums are enums generated in the file indexed by their Go name
+	enums map[string]model.Enum
+	// imports are imports required by generated code
+	imports map[string]bool
+	// vars are names of package level variables declared by generated code
+	vars map[string]bool
+}
+
+func newFileTypes(f model.File) *fileTypes {
+	ft := &fileTypes{
+		dataTypes: make(map[string]model.DataType, len(f.DataTypes)),
+		enums:     make(map[string]model.Enum, len(f.Enums)),
+		imports:   make(map[string]bool),
+		vars:      make(map[string]bool),
+	}
+	for _, dt := range f.DataTypes {
+		ft.dataTypes[dt.Name] = dt
+	}
+	for _, e := range f.Enums {
+		ft.enums[e.Name] = e
+	}
+	return ft
+}
+
+// packageVar returns a unique unexported name for a package level variable declared by generated code
+func (ft *fileTypes) packageVar(name string) string {
+	name = unexportedName(name)
+	v := name
+	for i := 2; ft.vars[v]; i++ {
+		v = fmt.Sprintf("%s%d", name, i)
+	}
+	ft.vars[v] = true
+	return v
+}
+
+// unexportedName returns a Go identifier starting with a lower case letter, a leading acronym is
+// lowered as a whole (URLSpec gives urlSpec)
+func unexportedName(name string) string {
+	r := []rune(name)
+	for i := 0; i < len(r) && unicode.IsUpper(r[i]); i++ {
+		if i > 0 && i+1 < len(r) && unicode.IsLower(r[i+1]) {
+			break
+		}
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
@@ -0,0 +1,772 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ystia/tdt2go/pkg/generator/builtin"
+	"github.com/ystia/tdt2go/pkg/model"
+)
+
+// validationGenerator generates Validate methods enforcing TOSCA constraints on data types.
+//
+// All data types generated in the file have a Validate method while enums don't.
+type validationGenerator struct {
+	*fileTypes
+	// decls are package level declarations used by the generated Validate method
+	decls strings.Builder
+}
+
+// validateMethod generates the Validate method of a data type preceded by package level declarations it uses
+func (vg *validationGenerator) validateMethod(dt model.DataType) (string, error) {
+	vg.imports["fmt"] = true
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "// Validate checks that %s values respect constraints defined in TOSCA\n", dt.Name)
+	fmt.Fprintf(b, "func (v %s) Validate() error {\n", dt.Name)
+	if dt.DerivedFrom != "" {
+		if isStructType(dt) {
+			vg.writeNestedValidation(b, "v."+embeddedFieldName(dt.DerivedFrom), dt.DerivedFrom, "")
+		} else {
+			vg.writeNestedValidation(b, dt.DerivedFrom+"(v)", dt.DerivedFrom, "")
+		}
+	}
+	for _, c := range dt.Constraints {
+		// Zero values of data types deriving from primitive types are valid only if they respect constraints
+		value := constrainedValue{expr: "v", typ: dt.Name, goType: dt.UnderlyingType, name: dt.Name}
+		err := vg.writeConstraintCheck(b, value, c)
+		if err != nil {
+			return "", fmt.Errorf("data type %q: %w", dt.FQDTN, err)
+		}
+	}
+	for _, f := range dt.Fields {
+		desc := fmt.Sprintf("property %q", fieldOriginalName(f))
+		value := fieldConstrainedValue(f, desc)
+		value.name = dt.Name + f.Name
+		for _, c := range f.Constraints {
+			err := vg.writeConstraintCheck(b, value, c)
+			if err != nil {
+				return "", fmt.Errorf("%s of data type %q: %w", desc, dt.FQDTN, err)
+			}
+		}
+		if f.EntrySchema != nil {
+			err := vg.writeEntriesConstraintChecks(b, value, f.EntrySchema)
+			if err != nil {
+				return "", fmt.Errorf("entries of %s of data type %q: %w", desc, dt.FQDTN, err)
+			}
+		}
+		if !f.Refinement {
+			vg.writeFieldNestedValidation(b, f, value, "invalid "+desc)
+		}
+	}
+	b.WriteString("return nil\n}\n")
+	if vg.decls.Len() > 0 {
+		return vg.decls.String() + "\n" + b.String(), nil
+	}
+	return b.String(), nil
+}
+
+// isStructType returns true if the data type is generated as a struct (embedding its parent type if any)
+func isStructType(dt model.DataType) bool {
+	return dt.DerivedFrom == "" || len(dt.Fields) > 0
+}
+
+func embeddedFieldName(t string) string {
+	s := strings.Split(t, ".")
+	return s[len(s)-1]
+}
+
+func fieldOriginalName(f model.Field) string {
+	if f.OriginalName != "" {
+		return f.OriginalName
+	}
+	return f.Name
+}
+
+// constrainedValue is a value on which constraints are checked
+type constrainedValue struct {
+	// expr is the Go expression of the value
+	expr string
+	// typ is the Go type of the value expression
+	typ string
+	// goType is the builtin Go type underlying the value type
+	goType string
+	// desc is the value description used in error messages, it is empty for data types values
+	desc string
+	// presence is a condition that should be true for the value to be considered as present, if empty
+	// the value is always present
+	presence string
+	// skipZero is true if zero values should be considered as absent values
+	skipZero bool
+	// name is the base name of package level variables generated for constraints on the value
+	name string
+	// keys are the variables holding the indexes or keys of collections entries for values that are entries of
+	// (nested) collections, they are added to error messages
+	keys []string
+}
+
+// fieldConstrainedValue returns how to check constraints on a field.
+//
+// Pointer fields are checked when they are not nil, required fields are always checked and
+// other fields are checked only if they don't have a zero value (except booleans) as absent values
+// can't be distinguished from zero values.
+func fieldConstrainedValue(f model.Field, desc string) constrainedValue {
+	value := constrainedValue{
+		expr:     "v." + f.Name,
+		typ:      strings.TrimPrefix(f.Type, "*"),
+		goType:   f.UnderlyingType,
+		desc:     desc,
+		skipZero: !f.Required,
+	}
+	if value.goType == "" {
+		value.goType = value.typ
+	}
+	if strings.HasPrefix(f.Type, "*") {
+		value.presence = value.expr + " != nil"
+		value.expr = "*" + value.expr
+		value.skipZero = false
+	}
+	return value
+}
+
+// writeFieldNestedValidation generates code that calls the Validate method of a field value, like for
+// constraints, zero values of optional fields deriving from primitive types are considered as absent values.
+func (vg *validationGenerator) writeFieldNestedValidation(b *strings.Builder, f model.Field, value constrainedValue, errPrefix string) {
+	presence := ""
+	if value.skipZero && f.UnderlyingType != "" {
+		presence = nonZeroCondition(value, kindOf(value.goType))
+	}
+	if presence == "" || !vg.mayHaveValidateMethod(f.Type) {
+		vg.writeNestedValidation(b, "v."+f.Name, f.Type, errPrefix)
+		return
+	}
+	fmt.Fprintf(b, "if %s {\n", presence)
+	vg.writeNestedValidation(b, "v."+f.Name, f.Type, errPrefix)
+	b.WriteString("}\n")
+}
+
+// writeEntriesConstraintChecks generates code checking constraints of an entry schema on entries of a collection
+// value and, recursively, on entries of nested collections.
+func (vg *validationGenerator) writeEntriesConstraintChecks(b *strings.Builder, value constrainedValue, schema *model.EntrySchema) error {
+	elemType, ok := collectionElemType(value.typ)
+	if !ok {
+		return fmt.Errorf("entry_schema constraints are not supported on %s values", value.typ)
+	}
+	key := fmt.Sprintf("k%d", len(value.keys))
+	entry := constrainedValue{
+		expr:   fmt.Sprintf("e%d", len(value.keys)),
+		typ:    elemType,
+		goType: vg.underlyingType(elemType),
+		desc:   value.desc,
+		name:   value.name + "Entry",
+		keys:   append(append([]string{}, value.keys...), key),
+	}
+	body := &strings.Builder{}
+	for _, c := range schema.Constraints {
+		err := vg.writeConstraintCheck(body, entry, c)
+		if err != nil {
+			return err
+		}
+	}
+	if schema.EntrySchema != nil {
+		err := vg.writeEntriesConstraintChecks(body, entry, schema.EntrySchema)
+		if err != nil {
+			return err
+		}
+	}
+	if body.Len() > 0 {
+		fmt.Fprintf(b, "for %s, %s := range %s {\n%s}\n", key, entry.expr, value.expr, body.String())
+	}
+	return nil
+}
+
+// underlyingType returns the builtin Go type underlying a type, TOSCA builtin types are returned as is
+func (vg *validationGenerator) underlyingType(goType string) string {
+	if isBuiltinType(goType) {
+		return goType
+	}
+	if dt, ok := vg.dataTypes[goType]; ok && dt.UnderlyingType != "" {
+		return dt.UnderlyingType
+	}
+	if _, ok := vg.enums[goType]; ok {
+		return "string"
+	}
+	return goType
+}
+
+// writeNestedValidation generates code that calls the Validate method of a value or of its elements
+// for slices and maps.
+//
+// Data types generated in the same file are known to have a Validate method, builtin Go types
+// are known to don't have one, for other types the presence of the method is checked at runtime.
+//
+// If errPrefix is empty errors are returned as is, otherwise they are wrapped using errPrefix
+// followed by slices indexes or maps keys.
+func (vg *validationGenerator) writeNestedValidation(b *strings.Builder, expr, goType, errPrefix string) {
+	if !vg.mayHaveValidateMethod(goType) {
+		return
+	}
+	vg.writeNestedValidationAtDepth(b, expr, goType, errPrefix, nil)
+}
+
+func (vg *validationGenerator) writeNestedValidationAtDepth(b *strings.Builder, expr, goType, errPrefix string, keys []string) {
+	if strings.HasPrefix(goType, "*") {
+		fmt.Fprintf(b, "if %s != nil {\n", expr)
+		vg.writeNestedValidationAtDepth(b, expr, goType[1:], errPrefix, keys)
+		b.WriteString("}\n")
+		return
+	}
+	if elemType, ok := collectionElemType(goType); ok {
+		key := fmt.Sprintf("k%d", len(keys))
+		elem := fmt.Sprintf("e%d", len(keys))
+		fmt.Fprintf(b, "for %s, %s := range %s {\n", key, elem, expr)
+		vg.writeNestedValidationAtDepth(b, elem, elemType, errPrefix, append(keys, key))
+		b.WriteString("}\n")
+		return
+	}
+
+	onError := "return err"
+	if errPrefix != "" {
+		format := escapeFormat(errPrefix) + strings.Repeat("[%v]", len(keys)) + ": %w"
+		onError = fmt.Sprintf("return fmt.Errorf(%s)", strings.Join(append(append([]string{strconv.Quote(format)}, keys...), "err"), ", "))
+	}
+	if _, ok := vg.dataTypes[goType]; ok {
+		fmt.Fprintf(b, "if err := %s.Validate(); err != nil {\n%s\n}\n", expr, onError)
+		return
+	}
+	fmt.Fprintf(b, "if validator, ok := interface{}(%s).(interface{ Validate() error }); ok {\nif err := validator.Validate(); err != nil {\n%s\n}\n}\n", expr, onError)
+}
+
+// mayHaveValidateMethod returns false for types known to don't have a Validate method
+// like builtin Go types and collections of builtin types
+func (vg *validationGenerator) mayHaveValidateMethod(goType string) bool {
+	goType = strings.TrimPrefix(goType, "*")
+	if elemType, ok := collectionElemType(goType); ok {
+		return vg.mayHaveValidateMethod(elemType)
+	}
+	switch goType {
+	case "string", "int", "int64", "uint64", "float64", "bool", "time.Time", "interface{}":
+		return false
+	}
+	_, isEnum := vg.enums[goType]
+	return !isEnum
+}
+
+// collectionElemType returns the type of elements of a slice or map type
+func collectionElemType(goType string) (string, bool) {
+	if strings.HasPrefix(goType, "[]") {
+		return goType[2:], true
+	}
+	if strings.HasPrefix(goType, "map[") {
+		depth := 0
+		for i, c := range goType {
+			switch c {
+			case '[':
+				depth++
+			case ']':
+				depth--
+				if depth == 0 {
+					return goType[i+1:], true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+type valueKind int
+
+const (
+	unsupportedKind valueKind = iota
+	stringKind
+	intKind
+	floatKind
+	boolKind
+	collectionKind
+	versionKind
+	scalarUnitKind
+	rangeKind
+	timeKind
+)
+
+func kindOf(goType string) valueKind {
+	switch goType {
+	case "string":
+		return stringKind
+	case "int":
+		return intKind
+	case "float64":
+		return floatKind
+	case "bool":
+		return boolKind
+	case "Version":
+		return versionKind
+	case "Range":
+		return rangeKind
+	case "time.Time", "Timestamp":
+		return timeKind
+	case "ScalarUnitSize", "ScalarUnitTime", "ScalarUnitFrequency", "ScalarUnitBitRate":
+		return scalarUnitKind
+	}
+	if _, ok := collectionElemType(goType); ok {
+		return collectionKind
+	}
+	return unsupportedKind
+}
+
+// constraintCheck is the generated code checking a TOSCA constraint on a value
+type constraintCheck struct {
+	// cond is the condition that the value should validate, it is empty if the constraint is not supported
+	// on the value type
+	cond string
+	// expected describes what is expected from the value in error messages
+	expected string
+	// comparisons are comparisons of the value that may fail, they are evaluated before cond which refers to
+	// the result of the i-th comparison as ci
+	comparisons []string
+}
+
+// writeConstraintCheck generates the code checking a single TOSCA constraint on a value, it fails if the
+// constraint is not supported on the value type.
+//
+// Constraints are only enforced on present values, see fieldConstrainedValue.
+func (vg *validationGenerator) writeConstraintCheck(b *strings.Builder, value constrainedValue, c model.Constraint) error {
+	kind := kindOf(value.goType)
+	var check constraintCheck
+	var err error
+	switch kind {
+	case versionKind, scalarUnitKind:
+		check, err = vg.comparisonCheck(value, kind, c)
+	case rangeKind:
+		check.cond, check.expected, err = rangeCondition(value, c)
+	case timeKind:
+		check.cond, check.expected, err = vg.timeCondition(value, c)
+	default:
+		check.cond, check.expected, err = vg.constraintCondition(value, kind, c)
+	}
+	if err != nil {
+		return err
+	}
+	if check.cond == "" {
+		return fmt.Errorf("constraint %s is not supported on %s values", c.Operator, value.goType)
+	}
+	msg := "invalid value %v"
+	if value.desc != "" {
+		msg += " for " + escapeFormat(value.desc) + strings.Repeat("[%v]", len(value.keys))
+	}
+	args := strings.Join(append([]string{comparedExpr(value, kind)}, value.keys...), ", ")
+	presence := value.presence
+	if value.skipZero {
+		presence = nonZeroCondition(value, kind)
+	}
+	if len(check.comparisons) == 0 {
+		cond := fmt.Sprintf("!(%s)", check.cond)
+		if presence != "" {
+			cond = fmt.Sprintf("%s && !(%s)", presence, check.cond)
+		}
+		fmt.Fprintf(b, "if %s {\nreturn fmt.Errorf(%s, %s)\n}\n", cond, strconv.Quote(msg+": "+escapeFormat(check.expected)), args)
+		return nil
+	}
+	if presence != "" {
+		fmt.Fprintf(b, "if %s {\n", presence)
+	}
+	for i, cmp := range check.comparisons {
+		if i > 0 {
+			b.WriteString(" else ")
+		}
+		fmt.Fprintf(b, "if c%d, err := %s; err != nil {\nreturn fmt.Errorf(%s, %s, err)\n}", i, cmp, strconv.Quote(msg+": %w"), args)
+	}
+	fmt.Fprintf(b, " else if !(%s) {\nreturn fmt.Errorf(%s, %s)\n}\n", check.cond, strconv.Quote(msg+": "+escapeFormat(check.expected)), args)
+	if presence != "" {
+		b.WriteString("}\n")
+	}
+	return nil
+}
+
+// nonZeroCondition returns the condition that a value should validate to have a non-zero value,
+// an empty condition is returned if zero values can't be checked
+func nonZeroCondition(value constrainedValue, kind valueKind) string {
+	switch kind {
+	case stringKind, scalarUnitKind:
+		return value.expr + ` != ""`
+	case intKind, floatKind:
+		return value.expr + " != 0"
+	case collectionKind:
+		return "len(" + value.expr + ") != 0"
+	case versionKind, rangeKind:
+		return fmt.Sprintf("%s != (%s{})", value.expr, value.typ)
+	case timeKind:
+		return "!" + timeExpr(value) + ".IsZero()"
+	}
+	return ""
+}
+
+// timeExpr returns the expression of a timestamp value converted to a time.Time
+func timeExpr(value constrainedValue) string {
+	if value.typ == "time.Time" {
+		return value.expr
+	}
+	return fmt.Sprintf("time.Time(%s)", value.expr)
+}
+
+// comparedExpr returns the expression of a value converted to its builtin type for types which values are
+// compared using the Compare method of the builtin type or which are formatted by the builtin type
+func comparedExpr(value constrainedValue, kind valueKind) string {
+	if kind == timeKind && value.goType == "time.Time" {
+		return timeExpr(value)
+	}
+	if (kind == versionKind || kind == scalarUnitKind || kind == rangeKind) && value.typ != value.goType {
+		return fmt.Sprintf("%s(%s)", value.goType, value.expr)
+	}
+	return value.expr
+}
+
+// comparisonCheck returns the check of a TOSCA constraint on versions and scalar-units values which are
+// compared using the Compare method of their builtin type, comparisons of scalar-units fail on invalid values.
+func (vg *validationGenerator) comparisonCheck(value constrainedValue, kind valueKind, c model.Constraint) (constraintCheck, error) {
+	check := constraintCheck{}
+	compare := func(v interface{}) (string, error) {
+		l, err := comparedLiteral(v, value.goType)
+		if err != nil {
+			return "", err
+		}
+		cmp := fmt.Sprintf("%s.Compare(%s)", comparedExpr(value, kind), l)
+		if kind == versionKind {
+			return cmp, nil
+		}
+		check.comparisons = append(check.comparisons, cmp)
+		return fmt.Sprintf("c%d", len(check.comparisons)-1), nil
+	}
+	switch c.Operator {
+	case "equal", "greater_than", "greater_or_equal", "less_than", "less_or_equal":
+		result, err := compare(c.Values[0])
+		if err != nil {
+			return check, err
+		}
+		op, desc := comparisonOperator(c.Operator)
+		check.cond = fmt.Sprintf("%s %s 0", result, op)
+		check.expected = fmt.Sprintf("should be %s %v", desc, c.Values[0])
+	case "in_range":
+		conds := make([]string, 0, 2)
+		for i, op := range []string{">=", "<="} {
+			if s, ok := c.Values[i].(string); ok && strings.EqualFold(s, "UNBOUNDED") {
+				continue
+			}
+			result, err := compare(c.Values[i])
+			if err != nil {
+				return check, err
+			}
+			conds = append(conds, fmt.Sprintf("%s %s 0", result, op))
+		}
+		if len(conds) == 0 {
+			conds = append(conds, "true")
+		}
+		check.cond = strings.Join(conds, " && ")
+		check.expected = fmt.Sprintf("should be in range [%v, %v]", c.Values[0], c.Values[1])
+	case "valid_values":
+		conds := make([]string, 0, len(c.Values))
+		for _, v := range c.Values {
+			result, err := compare(v)
+			if err != nil {
+				return check, err
+			}
+			conds = append(conds, result+" == 0")
+		}
+		check.cond = strings.Join(conds, " || ")
+		check.expected = fmt.Sprintf("should be one of %v", c.Values)
+	case "length", "min_length", "max_length", "pattern":
+		// Not supported
+	default:
+		return check, fmt.Errorf("unknown constraint operator %q", c.Operator)
+	}
+	return check, nil
+}
+
+// rangeCondition returns the condition that a range value should validate to respect a TOSCA constraint and
+// a description of what is expected, a range is in a range if both its bounds are in it. An empty condition
+// is returned if the constraint is not supported on ranges.
+func rangeCondition(value constrainedValue, c model.Constraint) (string, string, error) {
+	switch c.Operator {
+	case "equal":
+		l, err := rangeLiteral(c.Values[0])
+		if err != nil {
+			return "", "", err
+		}
+		return fmt.Sprintf("%s == (%s)", comparedExpr(value, rangeKind), l), fmt.Sprintf("should be equal to %v", c.Values[0]), nil
+	case "valid_values":
+		conds := make([]string, 0, len(c.Values))
+		for _, v := range c.Values {
+			l, err := rangeLiteral(v)
+			if err != nil {
+				return "", "", err
+			}
+			conds = append(conds, fmt.Sprintf("%s == (%s)", comparedExpr(value, rangeKind), l))
+		}
+		return strings.Join(conds, " || "), fmt.Sprintf("should be one of %v", c.Values), nil
+	case "in_range":
+		conds := make([]string, 0, 2)
+		for i, bound := range c.Values {
+			if s, ok := bound.(string); ok && strings.EqualFold(s, "UNBOUNDED") {
+				continue
+			}
+			l, err := literal(bound, intKind)
+			if err != nil {
+				return "", "", err
+			}
+			if strings.HasPrefix(l, "-") {
+				return "", "", fmt.Errorf("invalid constraint value %v: range bounds can't be negative", bound)
+			}
+			if i == 0 {
+				conds = append(conds, fmt.Sprintf("%s.LowerBound >= %s", value.expr, l))
+				continue
+			}
+			conds = append(conds, fmt.Sprintf("!%s.Unbounded && %s.UpperBound <= %s", value.expr, value.expr, l))
+		}
+		if len(conds) == 0 {
+			conds = append(conds, "true")
+		}
+		return strings.Join(conds, " && "), fmt.Sprintf("should be in range [%v, %v]", c.Values[0], c.Values[1]), nil
+	case "greater_than", "greater_or_equal", "less_than", "less_or_equal", "length", "min_length", "max_length", "pattern":
+		// Not supported
+		return "", "", nil
+	}
+	return "", "", fmt.Errorf("unknown constraint operator %q", c.Operator)
+}
+
+// timeCondition returns the condition that a timestamp value should validate to respect a TOSCA constraint and
+// a description of what is expected, timestamps are compared as instants whatever their time zone. An empty
+// condition is returned if the constraint is not supported on timestamps.
+func (vg *validationGenerator) timeCondition(value constrainedValue, c model.Constraint) (string, string, error) {
+	expr := timeExpr(value)
+	compare := func(operator string, v interface{}) (string, error) {
+		l, err := vg.timeLiteral(v)
+		if err != nil {
+			return "", fmt.Errorf("invalid constraint value: %w", err)
+		}
+		switch operator {
+		case "greater_than":
+			return fmt.Sprintf("%s.After(%s)", expr, l), nil
+		case "greater_or_equal":
+			return fmt.Sprintf("!%s.Before(%s)", expr, l), nil
+		case "less_than":
+			return fmt.Sprintf("%s.Before(%s)", expr, l), nil
+		case "less_or_equal":
+			return fmt.Sprintf("!%s.After(%s)", expr, l), nil
+		}
+		return fmt.Sprintf("%s.Equal(%s)", expr, l), nil
+	}
+	switch c.Operator {
+	case "equal", "greater_than", "greater_or_equal", "less_than", "less_or_equal":
+		cond, err := compare(c.Operator, c.Values[0])
+		if err != nil {
+			return "", "", err
+		}
+		_, desc := comparisonOperator(c.Operator)
+		return cond, fmt.Sprintf("should be %s %v", desc, c.Values[0]), nil
+	case "in_range":
+		conds := make([]string, 0, 2)
+		for i, operator := range []string{"greater_or_equal", "less_or_equal"} {
+			if s, ok := c.Values[i].(string); ok && strings.EqualFold(s, "UNBOUNDED") {
+				continue
+			}
+			cond, err := compare(operator, c.Values[i])
+			if err != nil {
+				return "", "", err
+			}
+			conds = append(conds, cond)
+		}
+		if len(conds) == 0 {
+			conds = append(conds, "true")
+		}
+		return strings.Join(conds, " && "), fmt.Sprintf("should be in range [%v, %v]", c.Values[0], c.Values[1]), nil
+	case "valid_values":
+		conds := make([]string, 0, len(c.Values))
+		for _, v := range c.Values {
+			cond, err := compare("equal", v)
+			if err != nil {
+				return "", "", err
+			}
+			conds = append(conds, cond)
+		}
+		return strings.Join(conds, " || "), fmt.Sprintf("should be one of %v", c.Values), nil
+	case "length", "min_length", "max_length", "pattern":
+		// Not supported
+		return "", "", nil
+	}
+	return "", "", fmt.Errorf("unknown constraint operator %q", c.Operator)
+}
+
+// comparedLiteral returns the Go literal of a constraint value compared to versions or scalar-units values
+func comparedLiteral(v interface{}, goType string) (string, error) {
+	if goType == "Version" {
+		return versionLiteral(v)
+	}
+	s := fmt.Sprint(v)
+	var err error
+	switch goType {
+	case "ScalarUnitSize":
+		_, err = builtin.ParseScalarUnitSize(s)
+	case "ScalarUnitTime":
+		_, err = builtin.ParseScalarUnitTime(s)
+	case "ScalarUnitFrequency":
+		_, err = builtin.ParseScalarUnitFrequency(s)
+	case "ScalarUnitBitRate":
+		_, err = builtin.ParseScalarUnitBitRate(s)
+	}
+	if err != nil {
+		return "", fmt.Errorf("invalid constraint value: %w", err)
+	}
+	return fmt.Sprintf("%s(%s)", goType, strconv.Quote(s)), nil
+}
+
+// constraintCondition returns the condition that a value should validate to respect a TOSCA constraint and
+// a description of what is expected. An empty condition is returned if the constraint is not supported for
+// the value kind.
+func (vg *validationGenerator) constraintCondition(value constrainedValue, kind valueKind, c model.Constraint) (string, string, error) {
+	expr := value.expr
+	switch c.Operator {
+	case "equal", "greater_than", "greater_or_equal", "less_than", "less_or_equal":
+		if kind == unsupportedKind || kind == collectionKind || (kind == boolKind && c.Operator != "equal") {
+			return "", "", nil
+		}
+		l, err := literal(c.Values[0], kind)
+		if err != nil {
+			return "", "", err
+		}
+		op, desc := comparisonOperator(c.Operator)
+		return fmt.Sprintf("%s %s %s", expr, op, l), fmt.Sprintf("should be %s %v", desc, c.Values[0]), nil
+	case "in_range":
+		if kind != intKind && kind != floatKind && kind != stringKind {
+			return "", "", nil
+		}
+		conds := make([]string, 0, 2)
+		for i, op := range []string{">=", "<="} {
+			if s, ok := c.Values[i].(string); ok && strings.EqualFold(s, "UNBOUNDED") && kind != stringKind {
+				continue
+			}
+			l, err := literal(c.Values[i], kind)
+			if err != nil {
+				return "", "", err
+			}
+			conds = append(conds, fmt.Sprintf("%s %s %s", expr, op, l))
+		}
+		if len(conds) == 0 {
+			conds = append(conds, "true")
+		}
+		return strings.Join(conds, " && "), fmt.Sprintf("should be in range [%v, %v]", c.Values[0], c.Values[1]), nil
+	case "valid_values":
+		if kind == unsupportedKind || kind == collectionKind {
+			return "", "", nil
+		}
+		conds := make([]string, 0, len(c.Values))
+		for _, v := range c.Values {
+			l, err := literal(v, kind)
+			if err != nil {
+				return "", "", err
+			}
+			conds = append(conds, fmt.Sprintf("%s == %s", expr, l))
+		}
+		return strings.Join(conds, " || "), fmt.Sprintf("should be one of %v", c.Values), nil
+	case "length", "min_length", "max_length":
+		var lenExpr string
+		switch kind {
+		case stringKind:
+			vg.imports["unicode/utf8"] = true
+			lenExpr = fmt.Sprintf("utf8.RuneCountInString(string(%s))", expr)
+		case collectionKind:
+			lenExpr = fmt.Sprintf("len(%s)", expr)
+		default:
+			return "", "", nil
+		}
+		l, err := literal(c.Values[0], intKind)
+		if err != nil {
+			return "", "", err
+		}
+		switch c.Operator {
+		case "min_length":
+			return fmt.Sprintf("%s >= %s", lenExpr, l), fmt.Sprintf("length should be at least %s", l), nil
+		case "max_length":
+			return fmt.Sprintf("%s <= %s", lenExpr, l), fmt.Sprintf("length should be at most %s", l), nil
+		}
+		return fmt.Sprintf("%s == %s", lenExpr, l), fmt.Sprintf("length should be %s", l), nil
+	case "pattern":
+		if kind != stringKind {
+			return "", "", nil
+		}
+		pattern := fmt.Sprintf("^(?:%v)$", c.Values[0])
+		if _, err := regexp.Compile(pattern); err != nil {
+			return "", "", fmt.Errorf("invalid pattern constraint: %w", err)
+		}
+		vg.imports["regexp"] = true
+		name := vg.packageVar(value.name + "Pattern")
+		fmt.Fprintf(&vg.decls, "var %s = regexp.MustCompile(%s)\n", name, strconv.Quote(pattern))
+		return fmt.Sprintf("%s.MatchString(string(%s))", name, expr), fmt.Sprintf("should match pattern %q", c.Values[0]), nil
+	}
+	return "", "", fmt.Errorf("unknown constraint operator %q", c.Operator)
+}
+
+func comparisonOperator(operator string) (string, string) {
+	switch operator {
+	case "greater_than":
+		return ">", "greater than"
+	case "greater_or_equal":
+		return ">=", "greater than or equal to"
+	case "less_than":
+		return "<", "less than"
+	case "less_or_equal":
+		return "<=", "less than or equal to"
+	}
+	return "==", "equal to"
+}
+
+// literal returns the Go literal of a constraint value for the given kind of value
+func literal(v interface{}, kind valueKind) (string, error) {
+	switch kind {
+	case stringKind:
+		return strconv.Quote(fmt.Sprint(v)), nil
+	case intKind:
+		switch n := v.(type) {
+		case int:
+			return strconv.Itoa(n), nil
+		case float64:
+			if n == float64(int64(n)) {
+				return strconv.FormatInt(int64(n), 10), nil
+			}
+		}
+		return "", fmt.Errorf("invalid constraint value %v: expecting an integer", v)
+	case floatKind:
+		switch n := v.(type) {
+		case int:
+			return strconv.Itoa(n), nil
+		case float64:
+			return strconv.FormatFloat(n, 'g', -1, 64), nil
+		}
+		return "", fmt.Errorf("invalid constraint value %v: expecting a float", v)
+	case boolKind:
+		if b, ok := v.(bool); ok {
+			return strconv.FormatBool(b), nil
+		}
+		return "", fmt.Errorf("invalid constraint value %v: expecting a boolean", v)
+	}
+	return "", fmt.Errorf("unsupported constraint value %v", v)
+}
+
+// escapeFormat escapes a string to be used as a fmt format
+func escapeFormat(s string) string {
+	return strings.ReplaceAll(s, "%", "%%")
+}
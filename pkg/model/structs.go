@@ -0,0 +1,147 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// File is the representation of a Go source file to be generated
+type File struct {
+	// Package is the short package name as it should appear in source file
+	Package string
+	// Imports is a list of additional imports to add to the source file
+	Imports []string
+	// DataTypes are TOSCA DataTypes to be included in this source file
+	DataTypes []DataType
+	// Enums are string types restricted to a set of valid values to be included in this source file
+	Enums []Enum
+}
+
+// TypeKind is the kind of TOSCA definition a DataType is generated from
+type TypeKind int
+
+const (
+	// DataTypeKind is the kind of types generated from TOSCA data types
+	DataTypeKind TypeKind = iota
+	// NodeTypePropertiesKind is the kind of types generated from properties of TOSCA node types
+	NodeTypePropertiesKind
+	// NodeTypeAttributesKind is the kind of types generated from attributes of TOSCA node types
+	NodeTypeAttributesKind
+	// CapabilityTypePropertiesKind is the kind of types generated from properties of TOSCA capability types
+	CapabilityTypePropertiesKind
+	// RelationshipTypePropertiesKind is the kind of types generated from properties of TOSCA relationship types
+	RelationshipTypePropertiesKind
+	// InterfaceTypeOperationInputsKind is the kind of types generated from inputs of operations of TOSCA interface types
+	InterfaceTypeOperationInputsKind
+	// NodeTypeOperationInputsKind is the kind of types generated from inputs of operations defined in the interfaces
+	// of TOSCA node types
+	NodeTypeOperationInputsKind
+)
+
+// DataType is the representation of a TOSCA datatype
+//
+// It is also used to represent other TOSCA definitions having properties like node types properties and
+// attributes, capability and relationship types properties or operations inputs, see Kind.
+type DataType struct {
+	// Name is the Go struct identifier name
+	Name string
+	// FQDTN is the Fully Qualified DataType Name in TOSCA, for other kinds of types it is the fully
+	// qualified name of the TOSCA type they are generated from
+	FQDTN string
+	// Kind is the kind of TOSCA definition this type is generated from
+	Kind TypeKind
+	// Operation is the name of the TOSCA operation types of operation inputs kinds are generated from,
+	// for node types it is prefixed by the interface name (like Standard.create)
+	Operation string
+	// DerivedFrom is the parent Go struct identifier name
+	DerivedFrom string
+	// FlattenedFrom is the parent Go struct identifier name of flattened structs, whose inherited fields
+	// are copied instead of embedding the parent struct. DerivedFrom is empty in this case.
+	//
+	// Structs are flattened when requested or when they refine inherited properties into a different Go type
+	// or make them required, as those fields could not be promoted from the parent struct.
+	FlattenedFrom string
+	// Description is the data type description field
+	Description string
+	// Fields are DataType fields (aka properties in TOSCA)
+	Fields []Field
+	// UnderlyingType is the builtin Go type this data type is derived from, directly or through its parents,
+	// it is empty if this data type doesn't derive from a TOSCA primitive type
+	UnderlyingType string
+	// Constraints are the TOSCA constraints applying to values of data types deriving from primitive types
+	Constraints []Constraint
+}
+
+// Field is the representation of a TOSCA datatype property
+type Field struct {
+	// Name is the Go struct field name
+	Name string
+	// OriginalName is the field name as it appear in the TOSCA definition
+	OriginalName string
+	// Type is the Go struct field type
+	Type string
+	// Description is the property description field
+	Description string
+	// Required is true if the TOSCA property is required
+	Required bool
+	// UnderlyingType is the builtin Go type the field type is derived from when it is a data type deriving
+	// from a TOSCA primitive type, it is empty otherwise
+	UnderlyingType string
+	// Constraints are the TOSCA constraints applying to the property
+	Constraints []Constraint
+	// EntrySchema holds the TOSCA constraints applying to entries of list and map properties,
+	// it is nil if their entry schema doesn't define constraints
+	EntrySchema *EntrySchema
+	// Default is the TOSCA default value of the property, nil if none
+	Default interface{}
+	// Inherited is true if the property is defined by a parent type and copied into a flattened struct
+	Inherited bool
+	// Refinement is true if the property refines a property of the embedded parent struct without changing
+	// its Go type nor its required flag. It is not generated as a struct field, the promoted parent field is used instead and only
+	// constraints added by the refinement are listed in Constraints.
+	Refinement bool
+}
+
+// EntrySchema is the representation of the constraints of a TOSCA entry schema
+type EntrySchema struct {
+	// Constraints are the TOSCA constraints applying to entries of the collection
+	Constraints []Constraint
+	// EntrySchema holds the constraints applying to entries of entries when they are also collections,
+	// it is nil if their entry schema doesn't define constraints
+	EntrySchema *EntrySchema
+}
+
+// Constraint is the representation of a TOSCA constraint clause
+type Constraint struct {
+	// Operator is the TOSCA constraint operator (like equal, in_range or valid_values)
+	Operator string
+	// Values are the constraint values, operators that takes a single value have a single element
+	Values []interface{}
+}
+
+// Enum is the representation of a string type restricted to a set of values by a TOSCA valid_values constraint
+type Enum struct {
+	// Name is the Go type identifier name
+	Name string
+	// Properties are the TOSCA properties using this enum, as "<FQDTN>.<property name>"
+	Properties []string
+	// Values are the enum valid values
+	Values []EnumValue
+}
+
+// EnumValue is the representation of a valid value of an Enum
+type EnumValue struct {
+	// Name is the Go constant identifier name
+	Name string
+	// Value is the constant value
+	Value string
+}
@@ -0,0 +1,102 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ystia/tdt2go/pkg/model"
+	"github.com/ystia/tdt2go/pkg/parser/internal/tosca"
+)
+
+// convertConstraints converts constraint clauses on values of a TOSCA type, constraint values are decoded
+// according to this type except for length and pattern operators values.
+func (p *Parser) convertConstraints(clauses []tosca.ConstraintClause, toscaType string, defs *definitions) ([]model.Constraint, error) {
+	if len(clauses) == 0 {
+		return nil, nil
+	}
+	constraints := make([]model.Constraint, 0, len(clauses))
+	for _, c := range clauses {
+		err := checkConstraintArity(c)
+		if err != nil {
+			return nil, err
+		}
+		valueType := toscaType
+		switch c.Operator {
+		case "length", "min_length", "max_length":
+			valueType = "integer"
+		case "pattern":
+			valueType = "string"
+		}
+		values := make([]interface{}, 0, len(c.Values))
+		for _, n := range c.Values {
+			v, err := p.decodeValue(n, valueType, nil, nil, defs)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of constraint operator %q: %w", c.Operator, err)
+			}
+			values = append(values, v)
+		}
+		constraints = append(constraints, model.Constraint{Operator: c.Operator, Values: values})
+	}
+	return constraints, nil
+}
+
+// convertEntrySchema converts the constraints of the entry schema of a collection type and of the entry
+// schemas of nested collections, it returns nil if none of them has constraints
+func (p *Parser) convertEntrySchema(toscaType string, schema *tosca.EntrySchema, defs *definitions) (*model.EntrySchema, error) {
+	switch strings.ToLower(toscaType) {
+	case "list", "map":
+	default:
+		return nil, nil
+	}
+	if schema == nil {
+		return nil, nil
+	}
+	constraints, err := p.convertConstraints(schema.Constraints, schema.Type, defs)
+	if err != nil {
+		return nil, err
+	}
+	nested, err := p.convertEntrySchema(schema.Type, schema.EntrySchema, defs)
+	if err != nil {
+		return nil, err
+	}
+	if len(constraints) == 0 && nested == nil {
+		return nil, nil
+	}
+	return &model.EntrySchema{Constraints: constraints, EntrySchema: nested}, nil
+}
+
+func checkConstraintArity(c tosca.ConstraintClause) error {
+	switch c.Operator {
+	case "equal", "greater_than", "greater_or_equal", "less_than", "less_or_equal", "length", "min_length", "max_length", "pattern":
+		if len(c.Values) != 1 {
+			return fmt.Errorf("constraint operator %q expects a single value, got %d", c.Operator, len(c.Values))
+		}
+	case "in_range":
+		if len(c.Values) != 2 {
+			return fmt.Errorf("constraint operator %q expects a list of two values, got %d", c.Operator, len(c.Values))
+		}
+	case "valid_values":
+		if len(c.Values) == 0 {
+			return fmt.Errorf("constraint operator %q expects a non-empty list of values", c.Operator)
+		}
+	case "schema":
+		return fmt.Errorf("constraint operator %q is not supported", c.Operator)
+	default:
+		return fmt.Errorf("unknown constraint operator %q", c.Operator)
+	}
+	return nil
+}
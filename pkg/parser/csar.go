@@ -0,0 +1,93 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const csarMetaFile = "TOSCA-Metadata/TOSCA.meta"
+
+// readerFileName is the name given to TOSCA definitions read from an io.Reader in error messages,
+// their imports are resolved relatively to the root directory of the file system
+const readerFileName = "<input>"
+
+// zipMagic is the signature of zip local file headers used to detect CSAR archives read from an io.Reader
+var zipMagic = []byte("PK\x03\x04")
+
+func isCSAR(filePath string) bool {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".csar", ".zip":
+		return true
+	}
+	return false
+}
+
+// csarEntryDefinitions returns the path of the entry definitions file of a CSAR.
+//
+// It is read from the Entry-Definitions keyname of the TOSCA.meta file, if the CSAR doesn't contain
+// a TOSCA.meta file then it should contain a single YAML file at its root.
+func csarEntryDefinitions(csar fs.FS) (string, error) {
+	meta, err := fs.ReadFile(csar, csarMetaFile)
+	if err == nil {
+		return parseEntryDefinitions(meta)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("failed to read %s: %w", csarMetaFile, err)
+	}
+	entries, err := fs.ReadDir(csar, ".")
+	if err != nil {
+		return "", err
+	}
+	var entryDefinitions string
+	for _, e := range entries {
+		ext := strings.ToLower(path.Ext(e.Name()))
+		if e.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		if entryDefinitions != "" {
+			return "", fmt.Errorf("no %s file and more than one YAML file at the root of the archive", csarMetaFile)
+		}
+		entryDefinitions = e.Name()
+	}
+	if entryDefinitions == "" {
+		return "", fmt.Errorf("no %s file and no YAML file at the root of the archive", csarMetaFile)
+	}
+	return entryDefinitions, nil
+}
+
+func parseEntryDefinitions(meta []byte) (string, error) {
+	s := bufio.NewScanner(bytes.NewReader(meta))
+	for s.Scan() {
+		kv := strings.SplitN(s.Text(), ":", 2)
+		if len(kv) == 2 && strings.TrimSpace(kv[0]) == "Entry-Definitions" {
+			entryDefinitions := strings.TrimSpace(kv[1])
+			if entryDefinitions != "" {
+				return path.Clean(entryDefinitions), nil
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", csarMetaFile, err)
+	}
+	return "", fmt.Errorf("missing Entry-Definitions keyname in %s", csarMetaFile)
+}
@@ -0,0 +1,181 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DerivationError is returned when the derived_from chain of a TOSCA type is invalid, either because
+// it is cyclic, because a type derives from a type of another kind (like a data type deriving from
+// a node type) or because a type derives from a type which is neither defined in the TOSCA definition
+// file nor in its imports
+type DerivationError struct {
+	// Kind is the kind of the TOSCA type (like data type or node type)
+	Kind string
+	// Chain is the derivation chain from the invalid type to the faulty parent
+	Chain []string
+	// Cyclic is true if the derivation chain loops, in this case the last type of the chain is
+	// the first one repeated in it
+	Cyclic bool
+	// ParentKind is the kind of the last type of the chain when it is not of the expected kind
+	ParentKind string
+	// Undefined is true if the last type of the chain is not defined
+	Undefined bool
+}
+
+func (e *DerivationError) Error() string {
+	chain := strings.Join(e.Chain, " -> ")
+	if e.Cyclic {
+		return fmt.Sprintf("cyclic derivation of %s %q: %s", e.Kind, e.Chain[0], chain)
+	}
+	if e.Undefined {
+		return fmt.Sprintf("%s %q derives from undefined type %q: %s", e.Kind, e.Chain[0], e.Chain[len(e.Chain)-1], chain)
+	}
+	return fmt.Sprintf("%s %q derives from %s %q: %s", e.Kind, e.Chain[0], e.ParentKind, e.Chain[len(e.Chain)-1], chain)
+}
+
+// derivationGraph is the derivation graph of the TOSCA types of a given kind
+type derivationGraph struct {
+	// kind is the kind of TOSCA types (like data type or node type)
+	kind string
+	// parents are the derived_from of types indexed by their fully qualified names
+	parents map[string]string
+	// isBuiltin returns true for parents which are not defined as TOSCA types of this kind (like primitive types)
+	isBuiltin func(name string) bool
+}
+
+func (defs *definitions) derivationGraphs() []derivationGraph {
+	graphs := []derivationGraph{
+		{kind: "data type", parents: make(map[string]string, len(defs.dataTypes)), isBuiltin: func(name string) bool {
+			return isKnownType(name, nil)
+		}},
+		{kind: "node type", parents: make(map[string]string, len(defs.nodeTypes))},
+		{kind: "capability type", parents: make(map[string]string, len(defs.capabilityTypes))},
+		{kind: "relationship type", parents: make(map[string]string, len(defs.relationshipTypes))},
+		{kind: "interface type", parents: make(map[string]string, len(defs.interfaceTypes))},
+	}
+	for name, t := range defs.dataTypes {
+		graphs[0].parents[name] = t.DerivedFrom
+	}
+	for name, t := range defs.nodeTypes {
+		graphs[1].parents[name] = t.DerivedFrom
+	}
+	for name, t := range defs.capabilityTypes {
+		graphs[2].parents[name] = t.DerivedFrom
+	}
+	for name, t := range defs.relationshipTypes {
+		graphs[3].parents[name] = t.DerivedFrom
+	}
+	for name, t := range defs.interfaceTypes {
+		graphs[4].parents[name] = t.DerivedFrom
+	}
+	return graphs
+}
+
+// checkDerivations checks derivation chains of all known TOSCA types, types are checked in the
+// alphabetical order of their names so a same error is always reported
+func (defs *definitions) checkDerivations() error {
+	graphs := defs.derivationGraphs()
+	for _, g := range graphs {
+		names := make([]string, 0, len(g.parents))
+		for name := range g.parents {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			err := checkDerivation(name, g, graphs)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func checkDerivation(name string, g derivationGraph, graphs []derivationGraph) error {
+	chain := []string{name}
+	visited := map[string]bool{name: true}
+	for parent := g.parents[name]; parent != ""; parent = g.parents[parent] {
+		chain = append(chain, parent)
+		if visited[parent] {
+			return &DerivationError{Kind: g.kind, Chain: chain, Cyclic: true}
+		}
+		visited[parent] = true
+		if _, ok := g.parents[parent]; ok {
+			continue
+		}
+		for _, other := range graphs {
+			if _, ok := other.parents[parent]; ok && other.kind != g.kind {
+				return &DerivationError{Kind: g.kind, Chain: chain, ParentKind: other.kind}
+			}
+		}
+		if isUndefined(parent, g, graphs) {
+			return &DerivationError{Kind: g.kind, Chain: chain, Undefined: true}
+		}
+		// Parent is a primitive type
+		break
+	}
+	return nil
+}
+
+// isUndefined returns true if a parent of a type of the graph g is neither a TOSCA type of any kind
+// nor a builtin type
+func isUndefined(parent string, g derivationGraph, graphs []derivationGraph) bool {
+	for _, other := range graphs {
+		if _, ok := other.parents[parent]; ok {
+			return false
+		}
+	}
+	return g.isBuiltin == nil || !g.isBuiltin(parent)
+}
+
+// dropUndefinedParents removes the parent of types deriving from undefined types, those types are then
+// converted as types without parent
+func (defs *definitions) dropUndefinedParents() {
+	graphs := defs.derivationGraphs()
+	for name, t := range defs.dataTypes {
+		if t.DerivedFrom != "" && isUndefined(t.DerivedFrom, graphs[0], graphs) {
+			t.DerivedFrom = ""
+			defs.dataTypes[name] = t
+		}
+	}
+	for name, t := range defs.nodeTypes {
+		if t.DerivedFrom != "" && isUndefined(t.DerivedFrom, graphs[1], graphs) {
+			t.DerivedFrom = ""
+			defs.nodeTypes[name] = t
+		}
+	}
+	for name, t := range defs.capabilityTypes {
+		if t.DerivedFrom != "" && isUndefined(t.DerivedFrom, graphs[2], graphs) {
+			t.DerivedFrom = ""
+			defs.capabilityTypes[name] = t
+		}
+	}
+	for name, t := range defs.relationshipTypes {
+		if t.DerivedFrom != "" && isUndefined(t.DerivedFrom, graphs[3], graphs) {
+			t.DerivedFrom = ""
+			defs.relationshipTypes[name] = t
+		}
+	}
+	for name, t := range defs.interfaceTypes {
+		if t.DerivedFrom != "" && isUndefined(t.DerivedFrom, graphs[4], graphs) {
+			t.DerivedFrom = ""
+			defs.interfaceTypes[name] = t
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ystia/tdt2go/pkg/model"
+)
+
+// ExtractEnums converts string properties restricted by a valid_values constraint into enums.
+//
+// It returns the given data types where those properties use the enum type instead of string and the
+// list of extracted enums. Enums are named after the property name (like ProtocolType for a protocol property),
+// properties with the same name and the same valid values share the same enum, otherwise names are prefixed
+// by the data type name to avoid collisions. Constants are named after the enum and their value, constants
+// whose name collides with the one of a data type, an enum or another constant are named after their index
+// in the valid values instead (like ProtocolValue1).
+func ExtractEnums(dataTypes []model.DataType) ([]model.DataType, []model.Enum) {
+	result := make([]model.DataType, len(dataTypes))
+	enums := make(map[string]*model.Enum)
+	// declared are names of data types, enums and enums constants
+	declared := make(map[string]bool, len(dataTypes))
+	for _, dt := range dataTypes {
+		declared[dt.Name] = true
+	}
+	for i, dt := range dataTypes {
+		fields := make([]model.Field, len(dt.Fields))
+		for j, f := range dt.Fields {
+			values := enumValues(f)
+			if values != nil {
+				e := getOrCreateEnum(enums, declared, dt.Name, f.Name, values)
+				e.Properties = append(e.Properties, dt.FQDTN+"."+f.OriginalName)
+				f.Type = strings.TrimSuffix(f.Type, "string") + e.Name
+				f.UnderlyingType = "string"
+			}
+			fields[j] = f
+		}
+		dt.Fields = fields
+		result[i] = dt
+	}
+
+	enumsList := make([]model.Enum, 0, len(enums))
+	for _, e := range enums {
+		enumsList = append(enumsList, *e)
+	}
+	sort.Slice(enumsList, func(i, j int) bool { return enumsList[i].Name < enumsList[j].Name })
+	return result, enumsList
+}
+
+// enumValues returns the valid values of a string (or string pointer) field or nil if it has
+// no valid_values constraint or if it is a refinement
+func enumValues(f model.Field) []string {
+	if f.Type != "string" && f.Type != "*string" || f.Refinement {
+		// Refinements use the promoted parent field so their type could not be changed
+		return nil
+	}
+	for _, c := range f.Constraints {
+		if c.Operator != "valid_values" {
+			continue
+		}
+		values := make([]string, 0, len(c.Values))
+		for _, v := range c.Values {
+			values = append(values, fmt.Sprint(v))
+		}
+		return values
+	}
+	return nil
+}
+
+func getOrCreateEnum(enums map[string]*model.Enum, declared map[string]bool, dtName, fieldName string, values []string) *model.Enum {
+	candidates := []string{fieldName + "Type", dtName + fieldName + "Type"}
+	for i := 2; ; i++ {
+		for _, name := range candidates {
+			e, ok := enums[name]
+			if ok && reflect.DeepEqual(enumStringValues(e), values) {
+				return e
+			}
+			if !ok && !declared[name] {
+				declared[name] = true
+				e = &model.Enum{Name: name, Values: convertEnumValues(name, values, declared)}
+				enums[name] = e
+				return e
+			}
+		}
+		candidates = []string{fmt.Sprintf("%s%s%dType", dtName, fieldName, i)}
+	}
+}
+
+func enumStringValues(e *model.Enum) []string {
+	values := make([]string, 0, len(e.Values))
+	for _, v := range e.Values {
+		values = append(values, v.Value)
+	}
+	return values
+}
+
+func convertEnumValues(enumName string, values []string, declared map[string]bool) []model.EnumValue {
+	prefix := strings.TrimSuffix(enumName, "Type")
+	result := make([]model.EnumValue, 0, len(values))
+	for i, v := range values {
+		name := prefix + convertToGoIdentifier(v)
+		if v == "" || declared[name] {
+			name = fmt.Sprintf("%sValue%d", prefix, i)
+			// Indexes past the valid values don't collide with names of other values
+			for j := len(values); declared[name]; j++ {
+				name = fmt.Sprintf("%sValue%d", prefix, j)
+			}
+		}
+		declared[name] = true
+		result = append(result, model.EnumValue{Name: name, Value: v})
+	}
+	return result
+}
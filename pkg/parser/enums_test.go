@@ -0,0 +1,120 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/ystia/tdt2go/pkg/model"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestExtractEnums(t *testing.T) {
+	protocolConstraint := model.Constraint{Operator: "valid_values", Values: []interface{}{"udp", "tcp"}}
+	tests := []struct {
+		name          string
+		dataTypes     []model.DataType
+		wantDataTypes []model.DataType
+		wantEnums     []model.Enum
+	}{
+		{"NoEnums", []model.DataType{
+			{Name: "MyDT", FQDTN: "org.ystia.datatypes.MyDT", Fields: []model.Field{
+				{Name: "Port", OriginalName: "port", Type: "int", Constraints: []model.Constraint{{Operator: "valid_values", Values: []interface{}{1, 2}}}},
+				{Name: "Name", OriginalName: "name", Type: "string", Constraints: []model.Constraint{{Operator: "min_length", Values: []interface{}{1}}}},
+			}},
+		}, []model.DataType{
+			{Name: "MyDT", FQDTN: "org.ystia.datatypes.MyDT", Fields: []model.Field{
+				{Name: "Port", OriginalName: "port", Type: "int", Constraints: []model.Constraint{{Operator: "valid_values", Values: []interface{}{1, 2}}}},
+				{Name: "Name", OriginalName: "name", Type: "string", Constraints: []model.Constraint{{Operator: "min_length", Values: []interface{}{1}}}},
+			}},
+		}, []model.Enum{}},
+		{"SharedAndCollidingEnums", []model.DataType{
+			{Name: "A", FQDTN: "org.ystia.datatypes.A", Fields: []model.Field{
+				{Name: "Protocol", OriginalName: "protocol", Type: "string", Constraints: []model.Constraint{protocolConstraint}},
+			}},
+			{Name: "B", FQDTN: "org.ystia.datatypes.B", Fields: []model.Field{
+				{Name: "Protocol", OriginalName: "protocol", Type: "string", Constraints: []model.Constraint{protocolConstraint}},
+				{Name: "Mode", OriginalName: "mode", Type: "string", Constraints: []model.Constraint{{Operator: "valid_values", Values: []interface{}{"a-b", "a_b", ""}}}},
+			}},
+			{Name: "C", FQDTN: "org.ystia.datatypes.C", Fields: []model.Field{
+				{Name: "Protocol", OriginalName: "protocol", Type: "string", Constraints: []model.Constraint{{Operator: "valid_values", Values: []interface{}{"icmp"}}}},
+			}},
+			{Name: "ModeType", FQDTN: "org.ystia.datatypes.ModeType"},
+		}, []model.DataType{
+			{Name: "A", FQDTN: "org.ystia.datatypes.A", Fields: []model.Field{
+				{Name: "Protocol", OriginalName: "protocol", Type: "ProtocolType", UnderlyingType: "string", Constraints: []model.Constraint{protocolConstraint}},
+			}},
+			{Name: "B", FQDTN: "org.ystia.datatypes.B", Fields: []model.Field{
+				{Name: "Protocol", OriginalName: "protocol", Type: "ProtocolType", UnderlyingType: "string", Constraints: []model.Constraint{protocolConstraint}},
+				{Name: "Mode", OriginalName: "mode", Type: "BModeType", UnderlyingType: "string", Constraints: []model.Constraint{{Operator: "valid_values", Values: []interface{}{"a-b", "a_b", ""}}}},
+			}},
+			{Name: "C", FQDTN: "org.ystia.datatypes.C", Fields: []model.Field{
+				{Name: "Protocol", OriginalName: "protocol", Type: "CProtocolType", UnderlyingType: "string", Constraints: []model.Constraint{{Operator: "valid_values", Values: []interface{}{"icmp"}}}},
+			}},
+			{Name: "ModeType", FQDTN: "org.ystia.datatypes.ModeType", Fields: []model.Field{}},
+		}, []model.Enum{
+			{Name: "BModeType", Properties: []string{"org.ystia.datatypes.B.mode"}, Values: []model.EnumValue{
+				{Name: "BModeAB", Value: "a-b"},
+				{Name: "BModeValue1", Value: "a_b"},
+				{Name: "BModeValue2", Value: ""},
+			}},
+			{Name: "CProtocolType", Properties: []string{"org.ystia.datatypes.C.protocol"}, Values: []model.EnumValue{
+				{Name: "CProtocolIcmp", Value: "icmp"},
+			}},
+			{Name: "ProtocolType", Properties: []string{"org.ystia.datatypes.A.protocol", "org.ystia.datatypes.B.protocol"}, Values: []model.EnumValue{
+				{Name: "ProtocolUDP", Value: "udp"},
+				{Name: "ProtocolTCP", Value: "tcp"},
+			}},
+		}},
+		{"CollidingConstants", []model.DataType{
+			{Name: "A", FQDTN: "org.acme.A", Fields: []model.Field{
+				{Name: "Protocol", OriginalName: "protocol", Type: "string", Constraints: []model.Constraint{{Operator: "valid_values", Values: []interface{}{"type", "tcp"}}}},
+			}},
+			{Name: "ProtocolTCP", FQDTN: "org.acme.ProtocolTCP"},
+		}, []model.DataType{
+			{Name: "A", FQDTN: "org.acme.A", Fields: []model.Field{
+				{Name: "Protocol", OriginalName: "protocol", Type: "ProtocolType", UnderlyingType: "string", Constraints: []model.Constraint{{Operator: "valid_values", Values: []interface{}{"type", "tcp"}}}},
+			}},
+			{Name: "ProtocolTCP", FQDTN: "org.acme.ProtocolTCP", Fields: []model.Field{}},
+		}, []model.Enum{
+			{Name: "ProtocolType", Properties: []string{"org.acme.A.protocol"}, Values: []model.EnumValue{
+				{Name: "ProtocolValue0", Value: "type"},
+				{Name: "ProtocolValue1", Value: "tcp"},
+			}},
+		}},
+		{"OptionalPointer", []model.DataType{
+			{Name: "A", FQDTN: "org.ystia.datatypes.A", Fields: []model.Field{
+				{Name: "Protocol", OriginalName: "protocol", Type: "*string", Constraints: []model.Constraint{protocolConstraint}},
+			}},
+		}, []model.DataType{
+			{Name: "A", FQDTN: "org.ystia.datatypes.A", Fields: []model.Field{
+				{Name: "Protocol", OriginalName: "protocol", Type: "*ProtocolType", UnderlyingType: "string", Constraints: []model.Constraint{protocolConstraint}},
+			}},
+		}, []model.Enum{
+			{Name: "ProtocolType", Properties: []string{"org.ystia.datatypes.A.protocol"}, Values: []model.EnumValue{
+				{Name: "ProtocolUDP", Value: "udp"},
+				{Name: "ProtocolTCP", Value: "tcp"},
+			}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotDataTypes, gotEnums := ExtractEnums(tt.dataTypes)
+			assert.DeepEqual(t, gotDataTypes, tt.wantDataTypes)
+			assert.DeepEqual(t, gotEnums, tt.wantEnums)
+		})
+	}
+}
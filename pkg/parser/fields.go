@@ -0,0 +1,229 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/ystia/tdt2go/pkg/model"
+	"github.com/ystia/tdt2go/pkg/parser/internal/tosca"
+)
+
+// propertiesLookup returns the parent and the properties of a TOSCA type of a given kind
+type propertiesLookup func(typeName string) (derivedFrom string, props map[string]tosca.PropertyDefinition, ok bool)
+
+// propertiesLookup returns a propertiesLookup for TOSCA types generated as the given kind
+func (defs *definitions) propertiesLookup(kind model.TypeKind) propertiesLookup {
+	return func(typeName string) (string, map[string]tosca.PropertyDefinition, bool) {
+		switch kind {
+		case model.DataTypeKind:
+			t, ok := defs.dataTypes[typeName]
+			return t.DerivedFrom, t.Properties, ok
+		case model.NodeTypePropertiesKind:
+			t, ok := defs.nodeTypes[typeName]
+			return t.DerivedFrom, t.Properties, ok
+		case model.NodeTypeAttributesKind:
+			t, ok := defs.nodeTypes[typeName]
+			return t.DerivedFrom, attributesAsProperties(t.Attributes), ok
+		case model.CapabilityTypePropertiesKind:
+			t, ok := defs.capabilityTypes[typeName]
+			return t.DerivedFrom, t.Properties, ok
+		case model.RelationshipTypePropertiesKind:
+			t, ok := defs.relationshipTypes[typeName]
+			return t.DerivedFrom, t.Properties, ok
+		}
+		return "", nil, false
+	}
+}
+
+// inheritedFieldNames returns the original names of properties of a TOSCA type and of its parents indexed by
+// the Go names of their fields, as they are promoted into structs embedding the type generated for typeName
+func (p *Parser) inheritedFieldNames(typeName string, lookup propertiesLookup) (map[string]string, error) {
+	chain := make([]string, 0)
+	visited := make(map[string]bool)
+	for typeName != "" && !visited[typeName] {
+		visited[typeName] = true
+		derivedFrom, _, ok := lookup(typeName)
+		if !ok {
+			break
+		}
+		chain = append(chain, typeName)
+		typeName = derivedFrom
+	}
+	inherited := make(map[string]string)
+	for i := len(chain) - 1; i >= 0; i-- {
+		_, props, _ := lookup(chain[i])
+		names, err := p.goFieldNames(chain[i], props, inherited)
+		if err != nil {
+			return nil, err
+		}
+		for pName, name := range names {
+			inherited[name] = pName
+		}
+	}
+	return inherited, nil
+}
+
+// goFieldNames returns the Go field names of properties indexed by properties names.
+//
+// Properties whose converted names collide with the ones of other properties (like my-field and my_field)
+// or with the ones of inherited fields are reported as an error, unless SuffixCollidingFields is set
+// in which case they are suffixed by a number following the alphabetical order of properties names.
+// The inherited map may contain an empty original name for the name of the embedded parent struct.
+func (p *Parser) goFieldNames(typeName string, props map[string]tosca.PropertyDefinition, inherited map[string]string) (map[string]string, error) {
+	pNames := make([]string, 0, len(props))
+	for pName := range props {
+		pNames = append(pNames, pName)
+	}
+	sort.Strings(pNames)
+	used := make(map[string]string, len(inherited)+len(props))
+	byProperty := make(map[string]string, len(inherited))
+	for name, pName := range inherited {
+		used[name] = pName
+		if pName != "" {
+			byProperty[pName] = name
+		}
+	}
+	names := make(map[string]string, len(props))
+	for _, pName := range pNames {
+		if name, ok := byProperty[pName]; ok {
+			// Refined properties keep the name of the inherited field
+			names[pName] = name
+			continue
+		}
+		name := convertToGoIdentifier(pName)
+		if other, ok := used[name]; ok && other != pName {
+			if !p.SuffixCollidingFields {
+				_, isInherited := inherited[name]
+				return nil, fieldNameCollisionError(typeName, pName, name, other, isInherited)
+			}
+			base := name
+			for i := 2; ok; i++ {
+				name = base + strconv.Itoa(i)
+				_, ok = used[name]
+			}
+		}
+		used[name] = pName
+		names[pName] = name
+	}
+	return names, nil
+}
+
+func fieldNameCollisionError(typeName, pName, name, other string, isInherited bool) error {
+	switch {
+	case isInherited && other == "":
+		return fmt.Errorf("property %q of %q is converted into Go field name %q which collides with its embedded parent type", pName, typeName, name)
+	case isInherited:
+		return fmt.Errorf("property %q of %q is converted into Go field name %q which collides with inherited property %q", pName, typeName, name, other)
+	}
+	return fmt.Errorf("property %q of %q is converted into Go field name %q which collides with property %q", pName, typeName, name, other)
+}
+
+// convertTypeFields converts properties of a TOSCA type deriving from derivedFrom into fields, it returns
+// true if the type is flattened.
+//
+// parent is the Go name of the struct generated for derivedFrom, empty if the type is not a struct deriving
+// from another struct. Properties redefining inherited ones are refined, see refineProperty.
+//
+// Inherited properties are promoted from the embedded parent struct, refined properties of the same Go type
+// and required flag as the inherited ones are flagged as refinements so they are not generated twice.
+// If FlattenStructs is set, or if a refinement changes the Go type or the required flag of an inherited
+// property (which could then not be promoted from the parent struct), inherited properties are instead
+// copied into the fields.
+func (p *Parser) convertTypeFields(typeName, derivedFrom, parent string, props map[string]tosca.PropertyDefinition, lookup propertiesLookup, defs *definitions) ([]model.Field, bool, error) {
+	inherited, err := p.inheritedFieldNames(derivedFrom, lookup)
+	if err != nil {
+		return nil, false, err
+	}
+	inheritedProps, err := p.inheritedProperties(derivedFrom, lookup, defs.dataTypes)
+	if err != nil {
+		return nil, false, err
+	}
+	flatten := p.FlattenStructs && parent != ""
+	refined := make(map[string]tosca.PropertyDefinition, len(props))
+	refinements := make(map[string]bool)
+	for pName, prop := range props {
+		parentProp, ok := inheritedProps[pName]
+		if !ok {
+			refined[pName] = prop
+			continue
+		}
+		r, err := p.refineProperty(typeName, pName, parentProp, prop, defs.dataTypes)
+		if err != nil {
+			return nil, false, err
+		}
+		refined[pName] = r
+		refinements[pName] = true
+		if parent != "" && (!p.sameFieldType(r, parentProp, defs.dataTypes) || isRequired(r) != isRequired(parentProp)) {
+			flatten = true
+		}
+	}
+	if flatten {
+		for pName, prop := range inheritedProps {
+			if _, ok := refined[pName]; !ok {
+				refined[pName] = prop
+			}
+		}
+		refinements = nil
+	} else {
+		for pName := range refinements {
+			// The field promoted from the parent struct is used, only constraints added
+			// by the refinement are checked on this type
+			r, prop := refined[pName], props[pName]
+			r.Constraints = prop.Constraints
+			r.EntrySchema = schemaWithoutConstraints(r.EntrySchema)
+			if prop.EntrySchema.Type != "" {
+				r.EntrySchema = prop.EntrySchema
+			}
+			refined[pName] = r
+		}
+		inherited = embeddingParent(inherited, parent)
+	}
+	fields, err := p.convertDTFields(typeName, refined, inherited, defs)
+	if err != nil {
+		return nil, false, err
+	}
+	for i, f := range fields {
+		_, isInherited := inheritedProps[f.OriginalName]
+		fields[i].Inherited = flatten && isInherited
+		fields[i].Refinement = refinements[f.OriginalName]
+	}
+	return fields, flatten, nil
+}
+
+// isRequired returns true if a property is required, properties are required by default
+func isRequired(prop tosca.PropertyDefinition) bool {
+	return prop.Required == nil || *prop.Required
+}
+
+// sameFieldType returns true if two properties are converted into fields of the same Go type
+func (p *Parser) sameFieldType(prop, other tosca.PropertyDefinition, dataTypes map[string]dataTypeDefinition) bool {
+	t, _, err := p.fieldType(prop, dataTypes)
+	if err != nil {
+		return false
+	}
+	otherType, _, err := p.fieldType(other, dataTypes)
+	return err == nil && t == otherType
+}
+
+// embeddingParent adds the name of the embedded parent struct to inherited field names
+func embeddingParent(inherited map[string]string, parent string) map[string]string {
+	if parent != "" {
+		inherited[parent] = ""
+	}
+	return inherited
+}
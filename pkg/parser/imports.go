@@ -0,0 +1,290 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ystia/tdt2go/pkg/parser/internal/tosca"
+)
+
+// definitionOrigin tracks where a TOSCA type is defined
+type definitionOrigin struct {
+	// file is the path of the TOSCA definition file defining the type
+	file string
+	// imported is true if the type is not defined in the root TOSCA definition file
+	imported bool
+}
+
+// dataTypeDefinition is a TOSCA data type definition along with the file defining it
+type dataTypeDefinition struct {
+	tosca.DataType
+	definitionOrigin
+}
+
+// nodeTypeDefinition is a TOSCA node type definition along with the file defining it
+type nodeTypeDefinition struct {
+	tosca.NodeType
+	definitionOrigin
+}
+
+// capabilityTypeDefinition is a TOSCA capability type definition along with the file defining it
+type capabilityTypeDefinition struct {
+	tosca.CapabilityType
+	definitionOrigin
+}
+
+// relationshipTypeDefinition is a TOSCA relationship type definition along with the file defining it
+type relationshipTypeDefinition struct {
+	tosca.RelationshipType
+	definitionOrigin
+}
+
+// interfaceTypeDefinition is a TOSCA interface type definition along with the file defining it
+type interfaceTypeDefinition struct {
+	tosca.InterfaceType
+	definitionOrigin
+}
+
+// definitions are TOSCA types known from a TOSCA definition file and its imports
+type definitions struct {
+	// dataTypes are all known data types indexed by their fully qualified names
+	dataTypes map[string]dataTypeDefinition
+	// nodeTypes are all known node types indexed by their fully qualified names
+	nodeTypes map[string]nodeTypeDefinition
+	// capabilityTypes are all known capability types indexed by their fully qualified names
+	capabilityTypes map[string]capabilityTypeDefinition
+	// relationshipTypes are all known relationship types indexed by their fully qualified names
+	relationshipTypes map[string]relationshipTypeDefinition
+	// interfaceTypes are all known interface types indexed by their fully qualified names
+	interfaceTypes map[string]interfaceTypeDefinition
+	// unresolved are references to unknown types found while converting properties, they are
+	// reported all at once at the end of the conversion
+	unresolved []UnresolvedTypeReference
+}
+
+// source is a TOSCA definition file to be loaded along with the file system it is read from
+type source struct {
+	// fsys is the file system the TOSCA definition file and its imports are read from
+	fsys fs.FS
+	// archive is the path of the CSAR archive fsys is read from, it is empty for other file systems
+	archive string
+	// filePath is the slash-separated path of the TOSCA definition file within fsys
+	filePath string
+	// content is the content of the TOSCA definition file if it is not read from fsys
+	content []byte
+}
+
+// name returns the name of a file of this source file system in error messages
+func (s source) name(filePath string) string {
+	if s.archive != "" {
+		return s.archive + "!/" + path.Clean(filePath)
+	}
+	return path.Clean(filePath)
+}
+
+// key returns the key identifying a file of this source file system in loaded files. Files read from
+// the operating system are identified by their absolute path, so a file reached through a relative
+// import and through an absolute path or an import path is loaded once.
+func (s source) key(filePath string) string {
+	if _, ok := s.fsys.(osFS); ok {
+		if abs, err := filepath.Abs(filepath.FromSlash(filePath)); err == nil {
+			return abs
+		}
+	}
+	return s.name(filePath)
+}
+
+// loadingFile is a TOSCA definition file being loaded
+type loadingFile struct {
+	// key identifies the file, see source.key
+	key string
+	// name is the file name used in error messages, see source.name
+	name string
+}
+
+// importsResolver loads TOSCA definition files and recursively all their imports to build
+// a unified graph of types
+type importsResolver struct {
+	parser *Parser
+	ctx    context.Context
+	// source is the TOSCA definition file currently being loaded, its imports are read from the same file system
+	source source
+	// roots are the keys of the loaded TOSCA definition files, their types are not considered as imported
+	// even if they are also imported by another file
+	roots map[string]bool
+	// loaded tracks files already loaded using their keys
+	loaded map[string]bool
+	// stack is the chain of files currently being loaded, used to detect circular imports
+	stack []loadingFile
+	definitions
+}
+
+// loadDefinitions loads the given TOSCA definition files into a unified graph of types, a type defined
+// in several files is reported as an error
+func (p *Parser) loadDefinitions(ctx context.Context, sources []source) (*definitions, error) {
+	r := &importsResolver{
+		parser: p,
+		ctx:    ctx,
+		roots:  make(map[string]bool),
+		loaded: make(map[string]bool),
+		definitions: definitions{
+			dataTypes:         make(map[string]dataTypeDefinition),
+			nodeTypes:         make(map[string]nodeTypeDefinition),
+			capabilityTypes:   make(map[string]capabilityTypeDefinition),
+			relationshipTypes: make(map[string]relationshipTypeDefinition),
+			interfaceTypes:    make(map[string]interfaceTypeDefinition),
+		},
+	}
+	for _, s := range sources {
+		r.roots[s.key(s.filePath)] = true
+	}
+	for _, s := range sources {
+		r.source = s
+		err := r.load(s.filePath, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &r.definitions, nil
+}
+
+func (r *importsResolver) load(filePath string, imported bool) error {
+	cleanPath := path.Clean(filePath)
+	name := r.source.name(filePath)
+	key := r.source.key(filePath)
+	for i, f := range r.stack {
+		if f.key == key {
+			chain := make([]string, 0, len(r.stack)-i+1)
+			for _, l := range r.stack[i:] {
+				chain = append(chain, l.name)
+			}
+			return fmt.Errorf("circular import detected: %s -> %s", strings.Join(chain, " -> "), name)
+		}
+	}
+	if r.loaded[key] {
+		return nil
+	}
+	if err := r.ctx.Err(); err != nil {
+		return err
+	}
+
+	var topo *tosca.Topology
+	var err error
+	if !imported && r.source.content != nil {
+		topo, err = r.parser.decodeTopology(name, r.source.content)
+	} else {
+		topo, err = r.parser.parseTopology(r.source.fsys, filePath)
+	}
+	if err != nil {
+		return err
+	}
+
+	r.stack = append(r.stack, loadingFile{key: key, name: name})
+	for _, imp := range topo.Imports {
+		importPath, err := r.resolveImport(path.Dir(cleanPath), imp)
+		if err != nil {
+			return fmt.Errorf("failed to resolve imports of %q: %w", name, err)
+		}
+		err = r.load(importPath, true)
+		if err != nil {
+			return err
+		}
+	}
+	r.stack = r.stack[:len(r.stack)-1]
+	r.loaded[key] = true
+
+	origin := definitionOrigin{file: name, imported: imported && !r.roots[key]}
+	for name, t := range topo.DataTypes {
+		if existing, ok := r.dataTypes[name]; ok {
+			return duplicateTypeError("data type", name, existing.file, origin.file)
+		}
+		r.dataTypes[name] = dataTypeDefinition{t, origin}
+	}
+	for name, t := range topo.NodeTypes {
+		if existing, ok := r.nodeTypes[name]; ok {
+			return duplicateTypeError("node type", name, existing.file, origin.file)
+		}
+		r.nodeTypes[name] = nodeTypeDefinition{t, origin}
+	}
+	for name, t := range topo.CapabilityTypes {
+		if existing, ok := r.capabilityTypes[name]; ok {
+			return duplicateTypeError("capability type", name, existing.file, origin.file)
+		}
+		r.capabilityTypes[name] = capabilityTypeDefinition{t, origin}
+	}
+	for name, t := range topo.RelationshipTypes {
+		if existing, ok := r.relationshipTypes[name]; ok {
+			return duplicateTypeError("relationship type", name, existing.file, origin.file)
+		}
+		r.relationshipTypes[name] = relationshipTypeDefinition{t, origin}
+	}
+	for name, t := range topo.InterfaceTypes {
+		if existing, ok := r.interfaceTypes[name]; ok {
+			return duplicateTypeError("interface type", name, existing.file, origin.file)
+		}
+		r.interfaceTypes[name] = interfaceTypeDefinition{t, origin}
+	}
+	return nil
+}
+
+func duplicateTypeError(kind, name, file, otherFile string) error {
+	return fmt.Errorf("%s %q is defined in both %q and %q", kind, name, file, otherFile)
+}
+
+// resolveImport looks for an imported file relatively to the importing file directory first
+// then into the parser import paths
+func (r *importsResolver) resolveImport(baseDir string, imp tosca.ImportDefinition) (string, error) {
+	if imp.Repository != "" {
+		return "", fmt.Errorf("import of %q from repository %q is not supported", imp.File, imp.Repository)
+	}
+	// Strip Yorc's builtin definitions notation (like <normative-types.yml>)
+	file := strings.TrimSuffix(strings.TrimPrefix(imp.File, "<"), ">")
+	if file == "" {
+		return "", fmt.Errorf("empty import definition")
+	}
+	file = filepath.ToSlash(file)
+	if path.IsAbs(file) || filepath.IsAbs(filepath.FromSlash(file)) {
+		return file, nil
+	}
+	candidates := []string{baseDir}
+	for _, p := range r.parser.ImportPaths {
+		candidates = append(candidates, filepath.ToSlash(p))
+	}
+	for _, dir := range candidates {
+		p := path.Join(dir, file)
+		if _, err := fs.Stat(r.source.fsys, p); err == nil {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("imported file %q not found in %q", imp.File, candidates)
+}
+
+// osFS is a fs.FS implementation that reads files from the operating system using slash-separated
+// paths that could be relative to the current working directory or absolute.
+//
+// Contrarily to os.DirFS it is not rooted to a given directory, so it allows TOSCA imports to refer
+// to parent directories.
+type osFS struct{}
+
+func (osFS) Open(name string) (fs.File, error) {
+	return os.Open(filepath.FromSlash(name))
+}
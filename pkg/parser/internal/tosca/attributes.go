@@ -14,14 +14,14 @@
 
 package tosca
 
-// An PropertyDefinition is the representation of a TOSCA Property Definition
+// An AttributeDefinition is the representation of a TOSCA Attribute Definition
 //
-// See http://docs.oasis-open.org/tosca/TOSCA-Simple-Profile-YAML/v1.2/TOSCA-Simple-Profile-YAML-v1.2.html#DEFN_ELEMENT_PROPERTY_DEFN for more details
-type PropertyDefinition struct {
-	Type        string `yaml:"type" json:"type"`
-	Description string `yaml:"description,omitempty" json:"description,omitempty"`
-	Required    *bool  `yaml:"required,omitempty" json:"required,omitempty"`
-	Status      string `yaml:"status,omitempty" json:"status,omitempty"`
-	//Constraints []ConstraintClause `yaml:"constraints,omitempty"`
+// See http://docs.oasis-open.org/tosca/TOSCA-Simple-Profile-YAML/v1.2/TOSCA-Simple-Profile-YAML-v1.2.html#DEFN_ELEMENT_ATTRIBUTE_DEFN for more details
+type AttributeDefinition struct {
+	Type        string      `yaml:"type" json:"type"`
+	Description string      `yaml:"description,omitempty" json:"description,omitempty"`
+	Default     *Value      `yaml:"default,omitempty" json:"default,omitempty"`
+	Status      string      `yaml:"status,omitempty" json:"status,omitempty"`
 	EntrySchema EntrySchema `yaml:"entry_schema,omitempty" json:"entry_schema,omitempty"`
+	KeySchema   EntrySchema `yaml:"key_schema,omitempty" json:"key_schema,omitempty"`
 }
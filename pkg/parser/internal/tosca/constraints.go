@@ -0,0 +1,47 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tosca
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A ConstraintClause is the representation of a TOSCA Constraint Clause
+//
+// See http://docs.oasis-open.org/tosca/TOSCA-Simple-Profile-YAML/v1.2/TOSCA-Simple-Profile-YAML-v1.2.html#DEFN_ELEMENT_CONSTRAINTS_CLAUSE for more details
+type ConstraintClause struct {
+	// Operator is the constraint operator keyname (like in_range or valid_values)
+	Operator string
+	// Values are the YAML nodes of the constraint values, operators that takes a single value have a single element.
+	// They are decoded according to the type of the constrained value.
+	Values []*yaml.Node
+}
+
+// UnmarshalYAML unmarshals a constraint clause defined as a single key map of an operator and its value(s)
+func (c *ConstraintClause) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode || len(node.Content) != 2 {
+		return fmt.Errorf("line %d: a constraint clause should be a single operator map", node.Line)
+	}
+	c.Operator = node.Content[0].Value
+	valueNode := node.Content[1]
+	if valueNode.Kind == yaml.SequenceNode {
+		c.Values = valueNode.Content
+		return nil
+	}
+	c.Values = []*yaml.Node{valueNode}
+	return nil
+}
@@ -14,13 +14,13 @@
 
 package tosca
 
-// An Topology is the representation of a TOSCA Service Template definition
+// An EntrySchema is the representation of a TOSCA Entry Schema
 //
-// See http://docs.oasis-open.org/tosca/TOSCA-Simple-Profile-YAML/v1.2/TOSCA-Simple-Profile-YAML-v1.2.html#DEFN_ELEMENT_SERVICE_TEMPLATE for more details
-type Topology struct {
-	TOSCAVersion string            `yaml:"tosca_definitions_version" json:"tosca_definitions_version"`
-	Description  string            `yaml:"description,omitempty" json:"description,omitempty"`
-	Metadata     map[string]string `yaml:"metadata,omitempty" json:"metadata,omitempty"`
-
-	DataTypes map[string]DataType `yaml:"data_types,omitempty" json:"data_types,omitempty"`
+// Entry schemas of collection types (list or map) have their own nested entry and key schemas.
+type EntrySchema struct {
+	Type        string             `yaml:"type" json:"type"`
+	Description string             `yaml:"description,omitempty" json:"description,omitempty"`
+	EntrySchema *EntrySchema       `yaml:"entry_schema,omitempty" json:"entry_schema,omitempty"`
+	KeySchema   *EntrySchema       `yaml:"key_schema,omitempty" json:"key_schema,omitempty"`
+	Constraints []ConstraintClause `yaml:"constraints,omitempty" json:"constraints,omitempty"`
 }
@@ -0,0 +1,56 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tosca
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// An ImportDefinition is the representation of a TOSCA Import Definition
+//
+// See http://docs.oasis-open.org/tosca/TOSCA-Simple-Profile-YAML/v1.2/TOSCA-Simple-Profile-YAML-v1.2.html#DEFN_ELEMENT_IMPORT_DEF for more details
+type ImportDefinition struct {
+	File            string `yaml:"file" json:"file"`
+	Repository      string `yaml:"repository,omitempty" json:"repository,omitempty"`
+	NamespaceURI    string `yaml:"namespace_uri,omitempty" json:"namespace_uri,omitempty"`
+	NamespacePrefix string `yaml:"namespace_prefix,omitempty" json:"namespace_prefix,omitempty"`
+}
+
+// UnmarshalYAML supports the single-line grammar, the multi-line grammar and the TOSCA 1.0
+// named import grammar (like "- my_import: some/file.yaml") of import definitions
+func (i *ImportDefinition) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Decode(&i.File)
+	case yaml.MappingNode:
+		if len(node.Content) == 2 && !isImportDefinitionKeyname(node.Content[0].Value) {
+			// Named import definition, the import definition is the value
+			return i.UnmarshalYAML(node.Content[1])
+		}
+		type rawImportDefinition ImportDefinition
+		return node.Decode((*rawImportDefinition)(i))
+	}
+	return fmt.Errorf("line %d: invalid import definition", node.Line)
+}
+
+func isImportDefinitionKeyname(k string) bool {
+	switch k {
+	case "file", "repository", "namespace_uri", "namespace_prefix":
+		return true
+	}
+	return false
+}
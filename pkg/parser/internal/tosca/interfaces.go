@@ -0,0 +1,171 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tosca
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// An InterfaceType is the representation of a TOSCA Interface Type
+//
+// Operations could be defined either directly as keys of the interface type (TOSCA 1.2) or under
+// an operations keyname (TOSCA 1.3).
+//
+// See http://docs.oasis-open.org/tosca/TOSCA-Simple-Profile-YAML/v1.2/TOSCA-Simple-Profile-YAML-v1.2.html#DEFN_ENTITY_INTERFACE_TYPE
+// for more details
+type InterfaceType struct {
+	Type       `yaml:",inline"`
+	Inputs     InputDefinitions               `yaml:"inputs,omitempty" json:"inputs,omitempty"`
+	Operations map[string]OperationDefinition `yaml:"operations,omitempty" json:"operations,omitempty"`
+}
+
+// UnmarshalYAML unmarshals an interface type and its operations
+func (it *InterfaceType) UnmarshalYAML(node *yaml.Node) error {
+	err := node.Decode(&it.Type)
+	if err != nil {
+		return err
+	}
+	it.Inputs, it.Operations, err = decodeInterfaceOperations(node, "derived_from", "version", "import_path", "description", "metadata")
+	return err
+}
+
+// An InterfaceDefinition is the representation of a TOSCA Interface Definition as found in the
+// interfaces section of node types
+//
+// See http://docs.oasis-open.org/tosca/TOSCA-Simple-Profile-YAML/v1.2/TOSCA-Simple-Profile-YAML-v1.2.html#DEFN_ELEMENT_INTERFACE_DEF
+// for more details
+type InterfaceDefinition struct {
+	Type       string                         `yaml:"type,omitempty" json:"type,omitempty"`
+	Inputs     InputDefinitions               `yaml:"inputs,omitempty" json:"inputs,omitempty"`
+	Operations map[string]OperationDefinition `yaml:"operations,omitempty" json:"operations,omitempty"`
+}
+
+// UnmarshalYAML unmarshals an interface definition and its operations
+func (i *InterfaceDefinition) UnmarshalYAML(node *yaml.Node) error {
+	var def struct {
+		Type string `yaml:"type"`
+	}
+	err := node.Decode(&def)
+	if err != nil {
+		return err
+	}
+	i.Type = def.Type
+	i.Inputs, i.Operations, err = decodeInterfaceOperations(node, "type", "description")
+	return err
+}
+
+// An OperationDefinition is the representation of a TOSCA Operation Definition
+//
+// See http://docs.oasis-open.org/tosca/TOSCA-Simple-Profile-YAML/v1.2/TOSCA-Simple-Profile-YAML-v1.2.html#DEFN_ELEMENT_OPERATION_DEF
+// for more details
+type OperationDefinition struct {
+	Description    string           `yaml:"description,omitempty" json:"description,omitempty"`
+	Implementation interface{}      `yaml:"implementation,omitempty" json:"implementation,omitempty"`
+	Inputs         InputDefinitions `yaml:"inputs,omitempty" json:"inputs,omitempty"`
+}
+
+// UnmarshalYAML unmarshals an operation definition either in its short notation (an implementation
+// artifact name) or in its extended notation
+func (o *OperationDefinition) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		o.Implementation = node.Value
+		return nil
+	}
+	type operationDefinition OperationDefinition
+	return node.Decode((*operationDefinition)(o))
+}
+
+// InputDefinitions are operation or interface inputs
+//
+// Inputs could either be property definitions or value assignments, only property definitions (maps having
+// a type keyname) are retained.
+type InputDefinitions map[string]PropertyDefinition
+
+// UnmarshalYAML unmarshals inputs keeping only property definitions
+func (in *InputDefinitions) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: inputs should be a map", node.Line)
+	}
+	*in = make(InputDefinitions)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		value := node.Content[i+1]
+		if !isPropertyDefinition(value) {
+			continue
+		}
+		var prop PropertyDefinition
+		err := value.Decode(&prop)
+		if err != nil {
+			return err
+		}
+		(*in)[node.Content[i].Value] = prop
+	}
+	return nil
+}
+
+func isPropertyDefinition(node *yaml.Node) bool {
+	if node.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "type" && node.Content[i+1].Kind == yaml.ScalarNode {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeInterfaceOperations decodes inputs and operations of an interface, keys that are neither
+// inputs, operations, notifications or one of the given keynames are considered as operations
+func decodeInterfaceOperations(node *yaml.Node, keynames ...string) (InputDefinitions, map[string]OperationDefinition, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("line %d: an interface should be a map", node.Line)
+	}
+	var inputs InputDefinitions
+	operations := make(map[string]OperationDefinition)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		var err error
+		switch {
+		case key == "inputs":
+			err = value.Decode(&inputs)
+		case key == "operations":
+			ops := make(map[string]OperationDefinition)
+			err = value.Decode(&ops)
+			for name, op := range ops {
+				operations[name] = op
+			}
+		case key == "notifications" || contains(keynames, key):
+		default:
+			var op OperationDefinition
+			err = value.Decode(&op)
+			operations[key] = op
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return inputs, operations, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ystia/tdt2go/pkg/model"
+)
+
+// NameCollisionError is returned when several TOSCA types are converted into a same Go type name
+type NameCollisionError struct {
+	// Collisions are the fully qualified names of the TOSCA types indexed by their colliding Go name
+	Collisions map[string][]string
+}
+
+func (e *NameCollisionError) Error() string {
+	names := make([]string, 0, len(e.Collisions))
+	for name := range e.Collisions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	collisions := make([]string, 0, len(names))
+	for _, name := range names {
+		collisions = append(collisions, fmt.Sprintf("%q is generated for %s", name, strings.Join(quoteAll(e.Collisions[name]), ", ")))
+	}
+	return "Go type names collisions: " + strings.Join(collisions, "; ")
+}
+
+// CheckNameCollisions returns a NameCollisionError if several data types have the same Go name
+func CheckNameCollisions(dataTypes []model.DataType) error {
+	fqdtns := make(map[string][]string, len(dataTypes))
+	for _, dt := range dataTypes {
+		fqdtns[dt.Name] = append(fqdtns[dt.Name], dt.FQDTN)
+	}
+	collisions := make(map[string][]string)
+	for name, names := range fqdtns {
+		if len(names) > 1 {
+			sort.Strings(names)
+			collisions[name] = names
+		}
+	}
+	if len(collisions) > 0 {
+		return &NameCollisionError{Collisions: collisions}
+	}
+	return nil
+}
+
+// disambiguatedNames returns Go names of known TOSCA types whose name collides with the one of another
+// type of the same kind. They are prefixed with as many namespace segments as required to distinguish
+// them (like org.a.Config and org.b.Config become AConfig and BConfig).
+func (p *Parser) disambiguatedNames(defs *definitions) map[string]string {
+	var dataTypes, nodeTypes, capabilityTypes, relationshipTypes, interfaceTypes []string
+	for name := range defs.dataTypes {
+		dataTypes = append(dataTypes, name)
+	}
+	for name := range defs.nodeTypes {
+		nodeTypes = append(nodeTypes, name)
+	}
+	for name := range defs.capabilityTypes {
+		capabilityTypes = append(capabilityTypes, name)
+	}
+	for name := range defs.relationshipTypes {
+		relationshipTypes = append(relationshipTypes, name)
+	}
+	for name := range defs.interfaceTypes {
+		interfaceTypes = append(interfaceTypes, name)
+	}
+
+	names := make(map[string]string)
+	for _, fqdtns := range [][]string{dataTypes, nodeTypes, capabilityTypes, relationshipTypes, interfaceTypes} {
+		groups := make(map[string][]string)
+		for _, fqdtn := range fqdtns {
+			name := p.convertDTName(fqdtn)
+			groups[name] = append(groups[name], fqdtn)
+		}
+		for _, group := range groups {
+			if len(group) < 2 {
+				continue
+			}
+			for fqdtn, name := range p.distinguishNames(group) {
+				names[fqdtn] = name
+			}
+		}
+	}
+	return names
+}
+
+// distinguishNames returns Go names of colliding TOSCA types built from the minimal number of their
+// trailing name segments that make them distinct
+func (p *Parser) distinguishNames(fqdtns []string) map[string]string {
+	segments := make(map[string][]string, len(fqdtns))
+	maxSegments := 0
+	for _, fqdtn := range fqdtns {
+		s := strings.Split(p.applyNameMappings(fqdtn), ".")
+		segments[fqdtn] = s
+		if len(s) > maxSegments {
+			maxSegments = len(s)
+		}
+	}
+	var names map[string]string
+	for n := 2; n <= maxSegments; n++ {
+		names = make(map[string]string, len(fqdtns))
+		used := make(map[string]bool, len(fqdtns))
+		for _, fqdtn := range fqdtns {
+			s := segments[fqdtn]
+			if n < len(s) {
+				s = s[len(s)-n:]
+			}
+			b := strings.Builder{}
+			for _, segment := range s {
+				b.WriteString(convertToGoIdentifier(segment))
+			}
+			names[fqdtn] = b.String()
+			used[b.String()] = true
+		}
+		if len(used) == len(fqdtns) {
+			break
+		}
+	}
+	return names
+}
+
+func quoteAll(values []string) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = fmt.Sprintf("%q", v)
+	}
+	return result
+}
@@ -0,0 +1,223 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"strings"
+
+	"github.com/ystia/tdt2go/pkg/model"
+	"github.com/ystia/tdt2go/pkg/parser/internal/tosca"
+)
+
+// convertInterfaceTypes converts inputs of operations of interface types into model.DataTypes named
+// <Interface><Operation>Inputs, derived from the closest parent interface type defining inputs for
+// the same operation
+func (p *Parser) convertInterfaceTypes(defs *definitions) ([]model.DataType, error) {
+	result := make([]model.DataType, 0)
+	for itName, it := range defs.interfaceTypes {
+		selected, err := p.isSelected(itName, it.definitionOrigin)
+		if err != nil {
+			return nil, err
+		}
+		if !selected {
+			continue
+		}
+		for opName, op := range it.Operations {
+			parent := p.interfaceOperationInputsType(it.DerivedFrom, opName, defs)
+			inputs := operationInputs(it.Inputs, op)
+			if len(inputs) == 0 && parent == "" {
+				continue
+			}
+			dt, err := p.convertOperationInputs(itName, opName, op, inputs, parent, defs.operationInputsLookup("", "", opName), defs)
+			if err != nil {
+				return nil, err
+			}
+			dt.Name = p.convertDTName(itName) + convertToGoIdentifier(opName) + "Inputs"
+			dt.Kind = model.InterfaceTypeOperationInputsKind
+			result = append(result, dt)
+		}
+	}
+	return result, nil
+}
+
+// convertNodeTypesOperations converts inputs of operations defined in interfaces of node types into
+// model.DataTypes named <Type><Interface><Operation>Inputs, derived from the closest parent node type
+// defining inputs for the same operation or else from the interface type ones
+func (p *Parser) convertNodeTypesOperations(defs *definitions) ([]model.DataType, error) {
+	result := make([]model.DataType, 0)
+	for ntName, nt := range defs.nodeTypes {
+		selected, err := p.isSelected(ntName, nt.definitionOrigin)
+		if err != nil {
+			return nil, err
+		}
+		if !selected {
+			continue
+		}
+		for ifName, iface := range nt.Interfaces {
+			for opName, op := range iface.Operations {
+				parent := p.nodeTypeOperationInputsType(nt.DerivedFrom, ifName, opName, defs)
+				if parent == "" {
+					parent = p.interfaceOperationInputsType(nodeTypeInterfaceType(ntName, ifName, defs), opName, defs)
+				}
+				inputs := operationInputs(iface.Inputs, op)
+				if len(inputs) == 0 && parent == "" {
+					continue
+				}
+				lookup := defs.operationInputsLookup(nodeTypeInterfaceType(ntName, ifName, defs), ifName, opName)
+				dt, err := p.convertOperationInputs(ntName, ifName+"."+opName, op, inputs, parent, lookup, defs)
+				if err != nil {
+					return nil, err
+				}
+				dt.Name = p.nodeTypeOperationInputsName(ntName, ifName, opName)
+				dt.Kind = model.NodeTypeOperationInputsKind
+				result = append(result, dt)
+			}
+		}
+	}
+	return result, nil
+}
+
+// convertOperationInputs converts inputs of an operation into a model.DataType derived from the given parent,
+// inputs redefining the ones of parent operations are refined like properties of derived types
+func (p *Parser) convertOperationInputs(typeName, opName string, op tosca.OperationDefinition, inputs map[string]tosca.PropertyDefinition, parent string, lookup propertiesLookup, defs *definitions) (model.DataType, error) {
+	derivedFrom, _, _ := lookup(typeName)
+	// Operations inputs structs embed their parent struct even if FlattenStructs is set
+	embedding := *p
+	embedding.FlattenStructs = false
+	fields, flattened, err := embedding.convertTypeFields(typeName+"."+opName, derivedFrom, parent, inputs, lookup, defs)
+	if err != nil {
+		return model.DataType{}, err
+	}
+	dt := model.DataType{
+		FQDTN:       typeName,
+		Operation:   opName,
+		DerivedFrom: parent,
+		Description: strings.Trim(op.Description, " \t\n"),
+		Fields:      fields,
+	}
+	if flattened {
+		dt.FlattenedFrom, dt.DerivedFrom = parent, ""
+	}
+	return dt, nil
+}
+
+// operationInputsLookup returns a propertiesLookup of inputs of an operation defined by interface types or,
+// if ifName is not empty, by an interface of node types. Node types without a known parent node type derive
+// from the interface type itName of their interface so inputs of node types operations inherit from the
+// interface type ones.
+func (defs *definitions) operationInputsLookup(itName, ifName, opName string) propertiesLookup {
+	return func(typeName string) (string, map[string]tosca.PropertyDefinition, bool) {
+		if nt, ok := defs.nodeTypes[typeName]; ok && ifName != "" {
+			derivedFrom := nt.DerivedFrom
+			if _, ok := defs.nodeTypes[derivedFrom]; !ok {
+				derivedFrom = itName
+			}
+			iface, ok := nt.Interfaces[ifName]
+			if !ok {
+				return derivedFrom, nil, true
+			}
+			op, ok := iface.Operations[opName]
+			if !ok {
+				return derivedFrom, nil, true
+			}
+			return derivedFrom, operationInputs(iface.Inputs, op), true
+		}
+		it, ok := defs.interfaceTypes[typeName]
+		if !ok {
+			return "", nil, false
+		}
+		op, ok := it.Operations[opName]
+		if !ok {
+			return it.DerivedFrom, nil, true
+		}
+		return it.DerivedFrom, operationInputs(it.Inputs, op), true
+	}
+}
+
+// operationInputs returns inputs definitions of an operation, including inputs common to all operations
+// of its interface
+func operationInputs(interfaceInputs tosca.InputDefinitions, op tosca.OperationDefinition) map[string]tosca.PropertyDefinition {
+	inputs := make(map[string]tosca.PropertyDefinition, len(interfaceInputs)+len(op.Inputs))
+	for name, input := range interfaceInputs {
+		inputs[name] = input
+	}
+	for name, input := range op.Inputs {
+		inputs[name] = input
+	}
+	return inputs
+}
+
+// interfaceOperationInputsType returns the Go name of the type generated for inputs of an operation
+// of the given interface type or of its closest parent defining inputs for this operation.
+// It returns an empty string if there is no such type.
+func (p *Parser) interfaceOperationInputsType(itName, opName string, defs *definitions) string {
+	visited := make(map[string]bool)
+	for itName != "" && !visited[itName] {
+		visited[itName] = true
+		it, ok := defs.interfaceTypes[itName]
+		if !ok {
+			return ""
+		}
+		if op, ok := it.Operations[opName]; ok && len(operationInputs(it.Inputs, op)) > 0 {
+			return p.convertDTName(itName) + convertToGoIdentifier(opName) + "Inputs"
+		}
+		itName = it.DerivedFrom
+	}
+	return ""
+}
+
+// nodeTypeOperationInputsType returns the Go name of the type generated for inputs of an operation
+// of an interface of the given node type or of its closest parent defining inputs for this operation.
+// It returns an empty string if there is no such type.
+func (p *Parser) nodeTypeOperationInputsType(ntName, ifName, opName string, defs *definitions) string {
+	visited := make(map[string]bool)
+	for ntName != "" && !visited[ntName] {
+		visited[ntName] = true
+		nt, ok := defs.nodeTypes[ntName]
+		if !ok {
+			return ""
+		}
+		if iface, ok := nt.Interfaces[ifName]; ok {
+			if op, ok := iface.Operations[opName]; ok && len(operationInputs(iface.Inputs, op)) > 0 {
+				return p.nodeTypeOperationInputsName(ntName, ifName, opName)
+			}
+		}
+		ntName = nt.DerivedFrom
+	}
+	return ""
+}
+
+func (p *Parser) nodeTypeOperationInputsName(ntName, ifName, opName string) string {
+	s := strings.Split(ifName, ".")
+	return p.convertDTName(ntName) + convertToGoIdentifier(s[len(s)-1]) + convertToGoIdentifier(opName) + "Inputs"
+}
+
+// nodeTypeInterfaceType returns the interface type of an interface of a node type, as it may only be
+// defined by a parent node type
+func nodeTypeInterfaceType(ntName, ifName string, defs *definitions) string {
+	visited := make(map[string]bool)
+	for ntName != "" && !visited[ntName] {
+		visited[ntName] = true
+		nt, ok := defs.nodeTypes[ntName]
+		if !ok {
+			break
+		}
+		if iface, ok := nt.Interfaces[ifName]; ok && iface.Type != "" {
+			return iface.Type
+		}
+		ntName = nt.DerivedFrom
+	}
+	return ""
+}
@@ -0,0 +1,574 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/serenize/snaker"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ystia/tdt2go/pkg/model"
+	"github.com/ystia/tdt2go/pkg/parser/internal/tosca"
+)
+
+type dtSlice []model.DataType
+
+func (p dtSlice) Len() int      { return len(p) }
+func (p dtSlice) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p dtSlice) Less(i, j int) bool {
+	// Types generated from a same TOSCA type (like node types properties and attributes) are sorted by name
+	return p[i].FQDTN < p[j].FQDTN || p[i].FQDTN == p[j].FQDTN && p[i].Name < p[j].Name
+}
+
+type dtFieldsSlice []model.Field
+
+func (p dtFieldsSlice) Len() int           { return len(p) }
+func (p dtFieldsSlice) Less(i, j int) bool { return p[i].OriginalName < p[j].OriginalName }
+func (p dtFieldsSlice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+// Parser is the TOSCA parser used to extract model.DataType from a TOSCA definition file
+type Parser struct {
+	// IncludePatterns is a list of regular expression patterns that fully qualified names of TOSCA datatypes should
+	// validates to be included.
+	// If no patterns are provided then all datatypes are considered.
+	// IncludePatterns have the precedence over ExcludePatterns.
+	IncludePatterns []string
+	// ExcludePatterns is a list of regular expression patterns that fully qualified names of TOSCA datatypes should
+	// validates to be excluded.
+	// If no patterns are provided then all datatypes are considered.
+	// IncludePatterns have the precedence over ExcludePatterns.
+	ExcludePatterns []string
+	// NameMappings are regular expressions applied to TOSCA datatype fully qualified names to transform them into Go struct names
+	NameMappings map[string]string
+	// ImportPaths is a list of directories where TOSCA imports are searched for when they can't be found
+	// relatively to the importing file.
+	ImportPaths []string
+	// IncludeImportedTypes allows to also extract datatypes defined in imported files.
+	// By default only datatypes defined in the given TOSCA definition file are extracted.
+	IncludeImportedTypes bool
+	// OptionalPointers allows to use pointers as Go types of optional properties of scalar types
+	// so absent values could be distinguished from zero values.
+	OptionalPointers bool
+	// LenientTimestamps allows to use a generated Timestamp type accepting every YAML 1.1 timestamp forms
+	// as Go type of TOSCA timestamps instead of time.Time
+	LenientTimestamps bool
+	// ParseNodeTypes allows to also extract properties and attributes of node types as <Type>Properties
+	// and <Type>Attributes types. Include and exclude patterns also apply to node types names.
+	ParseNodeTypes bool
+	// ParseCapabilityTypes allows to also extract properties of capability types as <Type>CapabilityProperties
+	// types. Include and exclude patterns also apply to capability types names.
+	ParseCapabilityTypes bool
+	// ParseRelationshipTypes allows to also extract properties of relationship types as <Type>RelationshipProperties
+	// types. Include and exclude patterns also apply to relationship types names.
+	ParseRelationshipTypes bool
+	// ParseOperationInputs allows to also extract inputs of operations of interface types as
+	// <Interface><Operation>Inputs types and of operations defined in interfaces of node types as
+	// <Type><Interface><Operation>Inputs types. Include and exclude patterns also apply to interface
+	// types and node types names.
+	ParseOperationInputs bool
+	// UnresolvedTypesFallback is the Go type (like interface{} or map[string]interface{}) used for properties
+	// referencing TOSCA types that are neither defined in the TOSCA definition file nor in its imports.
+	// If empty, parsing fails with an UnresolvedTypesError listing every unresolved references.
+	// Types deriving from such unknown types are converted as types without parent if it is set and
+	// are otherwise reported as a DerivationError.
+	UnresolvedTypesFallback string
+	// DisambiguateNames allows to prefix Go names of TOSCA types whose last name segment collides with the one
+	// of another type of the same kind with their distinguishing namespace segments (like org.a.Config and
+	// org.b.Config become AConfig and BConfig)
+	DisambiguateNames bool
+	// SuffixCollidingFields allows to suffix Go names of fields colliding with other fields names (like my-field
+	// and my_field) or with inherited ones by a number instead of failing
+	SuffixCollidingFields bool
+	// FlattenStructs allows to copy inherited properties into structs generated for derived types instead
+	// of embedding the parent struct. Operations inputs structs still embed their parent struct.
+	FlattenStructs bool
+
+	// names are Go names of TOSCA types overriding the conversion of their fully qualified names
+	names map[string]string
+}
+
+func (p *Parser) nameValidatesPatterns(dtName string) (bool, error) {
+	if len(p.IncludePatterns) > 0 {
+		for _, i := range p.IncludePatterns {
+			matched, err := regexp.MatchString(i, dtName)
+			if err != nil {
+				return false, fmt.Errorf("invalid include pattern %q: %w", i, err)
+			}
+			if matched {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	if len(p.ExcludePatterns) > 0 {
+		for _, e := range p.ExcludePatterns {
+			matched, err := regexp.MatchString(e, dtName)
+			if err != nil {
+				return false, fmt.Errorf("invalid exclude pattern %q: %w", e, err)
+			}
+			if matched {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// ParseTypes parses a TOSCA definition file and extracts a list of model.DataType.
+//
+// TOSCA imports are recursively resolved to build a unified graph of data types, but only data types
+// defined in the given TOSCA file are extracted unless IncludeImportedTypes is set.
+//
+// filePath could also be a CSAR archive (a file with .csar or .zip extension), in this case its entry
+// definitions file is parsed and imports are resolved inside the archive.
+func (p *Parser) ParseTypes(filePath string) ([]model.DataType, error) {
+	return p.ParseTypesFiles(filePath)
+}
+
+// ParseTypesFiles is like ParseTypes but parses several TOSCA definition files or CSAR archives and merges
+// their types into a single graph, so types of a file could reference types of another file.
+//
+// Files imported by several of them are loaded once, a type defined in several files is reported as an error.
+func (p *Parser) ParseTypesFiles(filePaths ...string) ([]model.DataType, error) {
+	sources := make([]source, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		if !isCSAR(filePath) {
+			sources = append(sources, source{fsys: osFS{}, filePath: filepath.ToSlash(filePath)})
+			continue
+		}
+		csar, err := zip.OpenReader(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open CSAR %q: %w", filePath, err)
+		}
+		defer csar.Close()
+		s, err := csarSource(&csar.Reader, filepath.ToSlash(filePath))
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, s)
+	}
+	return p.parseTypes(context.Background(), sources)
+}
+
+// ParseTypesFS is like ParseTypes but reads the TOSCA definition file and its imports from the given
+// file system, filePath is a slash-separated path within fsys.
+//
+// It allows to parse TOSCA definitions embedded into a program or stored in a virtual file system.
+// The context is checked before loading each TOSCA definition file.
+func (p *Parser) ParseTypesFS(ctx context.Context, fsys fs.FS, filePath string) ([]model.DataType, error) {
+	return p.ParseTypesFSFiles(ctx, fsys, filePath)
+}
+
+// ParseTypesFSFiles is like ParseTypesFiles but reads the TOSCA definition files and their imports from the given
+// file system, filePaths are slash-separated paths within fsys. See ParseTypesFS.
+func (p *Parser) ParseTypesFSFiles(ctx context.Context, fsys fs.FS, filePaths ...string) ([]model.DataType, error) {
+	sources := make([]source, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		if !isCSAR(filePath) {
+			sources = append(sources, source{fsys: fsys, filePath: filePath})
+			continue
+		}
+		b, err := fs.ReadFile(fsys, filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open CSAR %q: %w", filePath, err)
+		}
+		csar, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open CSAR %q: %w", filePath, err)
+		}
+		s, err := csarSource(csar, filePath)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, s)
+	}
+	return p.parseTypes(ctx, sources)
+}
+
+// ParseTypesReader is like ParseTypes but reads the TOSCA definition from r, it could be either a YAML
+// TOSCA definition or a CSAR archive.
+//
+// Imports of a YAML TOSCA definition are resolved from fsys (or from the operating system file system if
+// fsys is nil) relatively to its root directory then into import paths. Imports of a CSAR archive are
+// resolved inside the archive.
+// The context is checked before loading each TOSCA definition file.
+func (p *Parser) ParseTypesReader(ctx context.Context, r io.Reader, fsys fs.FS) ([]model.DataType, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TOSCA definition: %w", err)
+	}
+	if bytes.HasPrefix(b, zipMagic) {
+		csar, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open CSAR %q: %w", readerFileName, err)
+		}
+		s, err := csarSource(csar, readerFileName)
+		if err != nil {
+			return nil, err
+		}
+		return p.parseTypes(ctx, []source{s})
+	}
+	if fsys == nil {
+		fsys = osFS{}
+	}
+	return p.parseTypes(ctx, []source{{fsys: fsys, filePath: readerFileName, content: b}})
+}
+
+// csarSource returns the source of the entry definitions file of a CSAR archive
+func csarSource(csar *zip.Reader, archive string) (source, error) {
+	entryDefinitions, err := csarEntryDefinitions(csar)
+	if err != nil {
+		return source{}, fmt.Errorf("invalid CSAR %q: %w", archive, err)
+	}
+	return source{fsys: csar, archive: archive, filePath: entryDefinitions}, nil
+}
+
+// parseTypes parses the given TOSCA definition files into a single graph of types
+func (p *Parser) parseTypes(ctx context.Context, sources []source) ([]model.DataType, error) {
+	defs, err := p.loadDefinitions(ctx, sources)
+	if err != nil {
+		return nil, err
+	}
+	if p.UnresolvedTypesFallback != "" {
+		defs.dropUndefinedParents()
+	}
+	err = defs.checkDerivations()
+	if err != nil {
+		return nil, err
+	}
+	if p.DisambiguateNames {
+		disambiguated := *p
+		disambiguated.names = p.disambiguatedNames(defs)
+		p = &disambiguated
+	}
+	dataTypes := defs.dataTypes
+	ts := make(dtSlice, 0)
+	for dtName, dt := range dataTypes {
+		selected, err := p.isSelected(dtName, dt.definitionOrigin)
+		if err != nil {
+			return nil, err
+		}
+		if !selected {
+			continue
+		}
+		underlyingType := p.underlyingType(dt.DerivedFrom, dataTypes)
+		var parent string
+		if underlyingType == "" {
+			parent = p.convertTOSCAType(dt.DerivedFrom)
+		}
+		fields, flattened, err := p.convertTypeFields(dtName, dt.DerivedFrom, parent, dt.Properties, defs.propertiesLookup(model.DataTypeKind), defs)
+		if err != nil {
+			return nil, err
+		}
+		constraints, err := p.convertConstraints(dt.Constraints, dtName, defs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid constraints on data type %q: %w", dtName, err)
+		}
+		t := model.DataType{
+			Name:           p.convertDTName(dtName),
+			FQDTN:          dtName,
+			DerivedFrom:    p.convertTOSCAType(dt.DerivedFrom),
+			Fields:         fields,
+			Description:    strings.Trim(dt.Description, " \t\n"),
+			UnderlyingType: underlyingType,
+			Constraints:    constraints,
+		}
+		if flattened {
+			t.FlattenedFrom, t.DerivedFrom = parent, ""
+		}
+		ts = append(ts, t)
+	}
+	for _, c := range []struct {
+		enabled bool
+		convert func(*definitions) ([]model.DataType, error)
+	}{
+		{p.ParseNodeTypes, p.convertNodeTypes},
+		{p.ParseCapabilityTypes, p.convertCapabilityTypes},
+		{p.ParseRelationshipTypes, p.convertRelationshipTypes},
+		{p.ParseOperationInputs, p.convertInterfaceTypes},
+		{p.ParseOperationInputs, p.convertNodeTypesOperations},
+	} {
+		if !c.enabled {
+			continue
+		}
+		types, err := c.convert(defs)
+		if err != nil {
+			return nil, err
+		}
+		ts = append(ts, types...)
+	}
+	if err := unresolvedTypesError(defs.unresolved); err != nil {
+		return nil, err
+	}
+	sort.Sort(ts)
+	return ts, nil
+}
+
+func (p *Parser) parseTopology(fsys fs.FS, filePath string) (*tosca.Topology, error) {
+	b, err := fs.ReadFile(fsys, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse TOSCA definition: %w", err)
+	}
+	return p.decodeTopology(filePath, b)
+}
+
+func (p *Parser) decodeTopology(filePath string, b []byte) (*tosca.Topology, error) {
+	topo := &tosca.Topology{}
+	err := yaml.Unmarshal(b, topo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse TOSCA definition %q: %w", filePath, err)
+	}
+	return topo, nil
+}
+
+// convertDTFields converts properties into fields, references to unknown types are recorded into
+// defs unless UnresolvedTypesFallback is set.
+//
+// inherited are the names of fields promoted from the embedded parent struct, see goFieldNames.
+func (p *Parser) convertDTFields(dtName string, props map[string]tosca.PropertyDefinition, inherited map[string]string, defs *definitions) ([]model.Field, error) {
+	dataTypes := defs.dataTypes
+	names, err := p.goFieldNames(dtName, props, inherited)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(dtFieldsSlice, 0)
+	for pName, prop := range props {
+		if p.UnresolvedTypesFallback == "" {
+			for _, t := range unresolvedTypes(prop, dataTypes) {
+				defs.unresolved = append(defs.unresolved, UnresolvedTypeReference{Type: t, Property: pName, ReferencedBy: dtName})
+			}
+		}
+		constraints, err := p.convertConstraints(prop.Constraints, prop.Type, defs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid constraints on property %q of data type %q: %w", pName, dtName, err)
+		}
+		entrySchema, err := p.convertEntrySchema(prop.Type, &prop.EntrySchema, defs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid entry_schema constraints on property %q of data type %q: %w", pName, dtName, err)
+		}
+		fieldType, underlyingType, err := p.fieldType(prop, dataTypes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid type of property %q of data type %q: %w", pName, dtName, err)
+		}
+		defaultValue, err := p.decodeValue(valueNode(prop.Default), prop.Type, &prop.EntrySchema, &prop.KeySchema, defs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default value of property %q of data type %q: %w", pName, dtName, err)
+		}
+		f := model.Field{
+			Name:           names[pName],
+			OriginalName:   pName,
+			Type:           fieldType,
+			Description:    strings.Trim(prop.Description, " \t\n"),
+			Required:       isRequired(prop),
+			UnderlyingType: underlyingType,
+			Constraints:    constraints,
+			EntrySchema:    entrySchema,
+			Default:        defaultValue,
+		}
+		fields = append(fields, f)
+	}
+	sort.Sort(fields)
+	return fields, nil
+}
+
+// fieldType returns the Go type of the field a property is converted into and its underlying type when it is
+// a data type deriving from a TOSCA primitive type
+func (p *Parser) fieldType(prop tosca.PropertyDefinition, dataTypes map[string]dataTypeDefinition) (string, string, error) {
+	goType, err := p.convertDTPropType(prop, dataTypes)
+	if err != nil {
+		return "", "", err
+	}
+	var underlyingType string
+	if !isTOSCAPrimitiveType(prop.Type) {
+		underlyingType = p.underlyingType(prop.Type, dataTypes)
+	}
+	required := isRequired(prop)
+	if p.OptionalPointers && !required && (isTOSCAPrimitiveType(prop.Type) || underlyingType != "") {
+		goType = "*" + goType
+	}
+	return goType, underlyingType, nil
+}
+
+func (p *Parser) convertDTPropType(prop tosca.PropertyDefinition, dataTypes map[string]dataTypeDefinition) (string, error) {
+	return p.convertCollectionType(prop.Type, &prop.EntrySchema, &prop.KeySchema, dataTypes)
+}
+
+// convertCollectionType returns the Go type of a TOSCA type, collection types (list and map) are
+// converted recursively according to their entry and key schemas
+func (p *Parser) convertCollectionType(toscaType string, entrySchema, keySchema *tosca.EntrySchema, dataTypes map[string]dataTypeDefinition) (string, error) {
+	switch strings.ToLower(toscaType) {
+	case "list":
+		elemType, err := p.convertSchemaType(entrySchema, dataTypes)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elemType, nil
+	case "map":
+		keyType, err := p.convertMapKeyType(keySchema, dataTypes)
+		if err != nil {
+			return "", err
+		}
+		elemType, err := p.convertSchemaType(entrySchema, dataTypes)
+		if err != nil {
+			return "", err
+		}
+		return "map[" + keyType + "]" + elemType, nil
+	default:
+		if p.UnresolvedTypesFallback != "" && toscaType != "" && !isKnownType(toscaType, dataTypes) {
+			return p.UnresolvedTypesFallback, nil
+		}
+		return p.convertTOSCAType(toscaType), nil
+	}
+}
+
+// convertSchemaType returns the Go type of entries of a collection defined by a TOSCA entry_schema
+func (p *Parser) convertSchemaType(schema *tosca.EntrySchema, dataTypes map[string]dataTypeDefinition) (string, error) {
+	if schema == nil {
+		schema = &tosca.EntrySchema{}
+	}
+	return p.convertCollectionType(schema.Type, schema.EntrySchema, schema.KeySchema, dataTypes)
+}
+
+// convertMapKeyType returns the Go type of keys of a map defined by a TOSCA key_schema, string by default.
+//
+// Only TOSCA primitive types and data types deriving from them are valid Go map keys, complex data types
+// may contain slices or maps which are not comparable.
+func (p *Parser) convertMapKeyType(keySchema *tosca.EntrySchema, dataTypes map[string]dataTypeDefinition) (string, error) {
+	if keySchema == nil || keySchema.Type == "" {
+		return "string", nil
+	}
+	if !isTOSCAPrimitiveType(keySchema.Type) && p.underlyingType(keySchema.Type, dataTypes) == "" {
+		return "", fmt.Errorf("key_schema type %q is not a valid Go map key type", keySchema.Type)
+	}
+	return p.convertTOSCAType(keySchema.Type), nil
+}
+
+// underlyingType returns the builtin Go type a TOSCA type derives from, directly or through its parents,
+// or an empty string if it doesn't derive from a TOSCA primitive type
+func (p *Parser) underlyingType(toscaType string, dataTypes map[string]dataTypeDefinition) string {
+	if t := p.primitiveType(toscaType, dataTypes); t != "" {
+		return p.convertTOSCAType(t)
+	}
+	return ""
+}
+
+// primitiveType returns the TOSCA primitive type a type is or derives from, an empty string if none
+func (p *Parser) primitiveType(toscaType string, dataTypes map[string]dataTypeDefinition) string {
+	visited := make(map[string]bool)
+	for toscaType != "" && !visited[toscaType] {
+		if isTOSCAPrimitiveType(toscaType) {
+			return toscaType
+		}
+		visited[toscaType] = true
+		dt, ok := dataTypes[toscaType]
+		if !ok {
+			return ""
+		}
+		toscaType = dt.DerivedFrom
+	}
+	return ""
+}
+
+func isTOSCAPrimitiveType(t string) bool {
+	switch t {
+	case "string", "integer", "boolean", "float", "timestamp", "version", "range",
+		"scalar-unit", "scalar-unit.size", "scalar-unit.time", "scalar-unit.frequency", "scalar-unit.bitrate":
+		return true
+	}
+	return false
+}
+
+func (p *Parser) convertTOSCAType(t string) string {
+	switch t {
+	case "string":
+		return "string"
+	case "integer":
+		return "int"
+	case "boolean":
+		return "bool"
+	case "float":
+		return "float64"
+	case "timestamp":
+		if p.LenientTimestamps {
+			return "Timestamp"
+		}
+		return "time.Time"
+	case "version":
+		return "Version"
+	case "range":
+		return "Range"
+	case "scalar-unit":
+		return "ScalarUnit"
+	case "scalar-unit.size":
+		return "ScalarUnitSize"
+	case "scalar-unit.time":
+		return "ScalarUnitTime"
+	case "scalar-unit.frequency":
+		return "ScalarUnitFrequency"
+	case "scalar-unit.bitrate":
+		return "ScalarUnitBitRate"
+	}
+	return p.convertDTName(t)
+}
+
+func convertToGoIdentifier(name string) string {
+	// Replace all non letter non digit caracters to _
+	b := strings.Builder{}
+	for i, c := range name {
+		if i == 0 && !unicode.IsLetter(c) {
+			// Use canonical exporting prefix for such rare cases
+			b.WriteRune('X')
+			if unicode.IsDigit(c) {
+				b.WriteRune(c)
+			}
+		} else if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			b.WriteRune('_')
+		} else {
+			b.WriteRune(c)
+		}
+	}
+	// Convert using snaker
+	return snaker.SnakeToCamel(b.String())
+}
+
+func (p *Parser) convertDTName(dtName string) string {
+	if name, ok := p.names[dtName]; ok {
+		return name
+	}
+	name := p.applyNameMappings(dtName)
+	s := strings.Split(name, ".")
+	name = s[len(s)-1]
+	name = convertToGoIdentifier(name)
+	return name
+}
+
+func (p *Parser) applyNameMappings(dtName string) string {
+	for pattern, subst := range p.NameMappings {
+		re := regexp.MustCompile(pattern)
+		dtName = re.ReplaceAllString(dtName, subst)
+	}
+	return dtName
+}
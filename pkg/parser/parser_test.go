@@ -0,0 +1,1162 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/ystia/tdt2go/pkg/model"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParser_ParseTypes(t *testing.T) {
+	type args struct {
+		filePath string
+	}
+	tests := []struct {
+		name    string
+		p       *Parser
+		args    args
+		want    []model.DataType
+		wantErr bool
+	}{
+		{"NoTOSCAFile", &Parser{}, args{"testdata/donotexists.yaml"}, nil, true},
+		{"InvalidTOSCAFile", &Parser{}, args{"testdata/invalid.yaml"}, nil, true},
+		{"InvalidIncludeFilter", &Parser{IncludePatterns: []string{`x{2,1}}`}}, args{"testdata/normative-light.yaml"}, nil, true},
+		{"InvalidExcludeFilter", &Parser{ExcludePatterns: []string{`x{2,1}}`}}, args{"testdata/normative-light.yaml"}, nil, true},
+		{"TestParseNormativeLight", &Parser{}, args{"testdata/normative-light.yaml"}, []model.DataType{
+			{
+				Name:        "Credential",
+				FQDTN:       "tosca.datatypes.Credential",
+				DerivedFrom: "Root",
+				Description: "The Credential type is a complex TOSCA data Type used when describing authorization credentials\nused to access network accessible resources.",
+				Fields: []model.Field{
+					{
+						Name:         "Keys",
+						OriginalName: "keys",
+						Type:         "map[string]string",
+						Description:  "The optional list of protocol-specific keys or assertions.",
+					},
+					{
+						Name:         "Protocol",
+						OriginalName: "protocol",
+						Type:         "string",
+						Description:  "The optional protocol name.",
+					},
+					{
+						Name:         "Token",
+						OriginalName: "token",
+						Type:         "string",
+						Required:     true,
+						Description:  "The required token used as a credential\nfor authorization or access to a networked resource.",
+					},
+					{
+						Name:         "TokenType",
+						OriginalName: "token_type",
+						Type:         "string",
+						Required:     true,
+						Description:  "The required token type.",
+						Default:      "password",
+					},
+					{
+						Name:         "User",
+						OriginalName: "user",
+						Type:         "string",
+						Description:  "The optional user (name or ID) used for non-token based credentials.",
+					},
+				},
+			},
+			{
+				Name:        "Root",
+				FQDTN:       "tosca.datatypes.Root",
+				Description: "The TOSCA root Data Type all other TOSCA base Data Types derive from",
+				Fields:      []model.Field{},
+			},
+			{
+				Name:        "TimeInterval",
+				FQDTN:       "tosca.datatypes.TimeInterval",
+				DerivedFrom: "Root",
+				Fields: []model.Field{
+					{
+						Name:         "EndTime",
+						OriginalName: "end_time",
+						Type:         "time.Time",
+						Required:     true,
+					},
+					{
+						Name:         "StartTime",
+						OriginalName: "start_time",
+						Type:         "time.Time",
+						Required:     true,
+					},
+				},
+			},
+		}, false},
+		{"TestParseWithNameMapping", &Parser{
+			NameMappings: map[string]string{
+				`tosca\.datatypes\.(R.+)`:        `TOSCA${1}`,
+				`tosca\.datatypes\.TimeInterval`: "ValidTimeInterval",
+			},
+		}, args{"testdata/normative-for-name-mapping.yaml"}, []model.DataType{
+			{
+				Name:        "Credential",
+				FQDTN:       "tosca.datatypes.Credential",
+				DerivedFrom: "TOSCARoot",
+				Description: "The Credential type is a complex TOSCA data Type used when describing authorization credentials used to access network accessible resources.",
+				Fields: []model.Field{
+					{
+						Name:         "Keys",
+						OriginalName: "keys",
+						Type:         "map[string]string",
+						Description:  "The optional list of protocol-specific keys or assertions.",
+					},
+					{
+						Name:         "Protocol",
+						OriginalName: "protocol",
+						Type:         "string",
+						Description:  "The optional protocol name.",
+					},
+					{
+						Name:         "Token",
+						OriginalName: "token",
+						Type:         "string",
+						Required:     true,
+						Description:  "The required token used as a credential for authorization or access to a networked resource.",
+					},
+					{
+						Name:         "TokenType",
+						OriginalName: "token_type",
+						Type:         "string",
+						Required:     true,
+						Description:  "The required token type.",
+						Default:      "password",
+					},
+					{
+						Name:         "User",
+						OriginalName: "user",
+						Type:         "string",
+						Description:  "The optional user (name or ID) used for non-token based credentials.",
+					},
+					{
+						Name:         "Validity",
+						OriginalName: "validity",
+						Type:         "ValidTimeInterval",
+					},
+				},
+			},
+			{
+				Name:        "TOSCARoot",
+				FQDTN:       "tosca.datatypes.Root",
+				Description: "The TOSCA root Data Type all other TOSCA base Data Types derive from",
+				Fields:      []model.Field{},
+			},
+			{
+				Name:        "ValidTimeInterval",
+				FQDTN:       "tosca.datatypes.TimeInterval",
+				DerivedFrom: "TOSCARoot",
+				Fields: []model.Field{
+					{
+						Name:         "EndTime",
+						OriginalName: "end_time",
+						Type:         "time.Time",
+						Required:     true,
+					},
+					{
+						Name:         "StartTime",
+						OriginalName: "start_time",
+						Type:         "time.Time",
+						Required:     true,
+					},
+				},
+			},
+		}, false},
+		{"UnresolvedTypes", &Parser{}, args{"testdata/extratypes.yaml"}, nil, true},
+		{"TestExtraToscaTypes", &Parser{UnresolvedTypesFallback: "map[string]interface{}"}, args{"testdata/extratypes.yaml"}, []model.DataType{
+			{
+				Name:  "SpecificTypes",
+				FQDTN: "tosca.datatypes.SpecificTypes",
+				Fields: []model.Field{
+					{
+						Name:         "X1Number",
+						OriginalName: "1_number",
+						Type:         "float64",
+						Required:     true,
+					},
+					{
+						Name:         "ARange",
+						OriginalName: "a_range",
+						Type:         "Range",
+						Required:     true,
+					},
+					{
+						Name:         "AScalarUnit",
+						OriginalName: "a_scalar_unit",
+						Type:         "ScalarUnit",
+						Required:     true,
+					},
+					{
+						Name:         "AScalarUnitBitrate",
+						OriginalName: "a_scalar_unit_bitrate",
+						Type:         "ScalarUnitBitRate",
+						Required:     true,
+					},
+					{
+						Name:         "AScalarUnitFrequency",
+						OriginalName: "a_scalar_unit_frequency",
+						Type:         "ScalarUnitFrequency",
+						Required:     true,
+					},
+					{
+						Name:         "AScalarUnitSize",
+						OriginalName: "a_scalar_unit_size",
+						Type:         "ScalarUnitSize",
+						Required:     true,
+					},
+					{
+						Name:         "AScalarUnitTime",
+						OriginalName: "a_scalar_unit_time",
+						Type:         "ScalarUnitTime",
+						Required:     true,
+					},
+					{
+						Name:         "AVersion",
+						OriginalName: "a_version",
+						Type:         "Version",
+						Required:     true,
+					},
+					{
+						Name:         "AnotherType",
+						OriginalName: "another_type",
+						Type:         "map[string]interface{}",
+						Required:     true,
+					},
+					{
+						Name:         "TestAList",
+						OriginalName: "test_a_list",
+						Type:         "[]int",
+					},
+					{
+						Name:         "ValidBoolID",
+						OriginalName: "valid_bool_id",
+						Type:         "bool",
+						Required:     true,
+					},
+				},
+			},
+			{
+				Name:           "JSON",
+				FQDTN:          "tosca.datatypes.json",
+				DerivedFrom:    "string",
+				Fields:         []model.Field{},
+				UnderlyingType: "string",
+			},
+		}, false},
+		{"TestParseIncludeFilters", &Parser{
+			IncludePatterns: []string{`tosca\.datatypes\.Cred.*`, `tosca.datatypes.Root`},
+			NameMappings:    map[string]string{`tosca\.datatypes\.TimeInterval`: "tosca.datatypes.CredButNotIncluded"},
+		}, args{"testdata/normative-light.yaml"}, []model.DataType{
+			{
+				Name:        "Credential",
+				FQDTN:       "tosca.datatypes.Credential",
+				DerivedFrom: "Root",
+				Description: "The Credential type is a complex TOSCA data Type used when describing authorization credentials\nused to access network accessible resources.",
+				Fields: []model.Field{
+					{
+						Name:         "Keys",
+						OriginalName: "keys",
+						Type:         "map[string]string",
+						Description:  "The optional list of protocol-specific keys or assertions.",
+					},
+					{
+						Name:         "Protocol",
+						OriginalName: "protocol",
+						Type:         "string",
+						Description:  "The optional protocol name.",
+					},
+					{
+						Name:         "Token",
+						OriginalName: "token",
+						Type:         "string",
+						Required:     true,
+						Description:  "The required token used as a credential\nfor authorization or access to a networked resource.",
+					},
+					{
+						Name:         "TokenType",
+						OriginalName: "token_type",
+						Type:         "string",
+						Required:     true,
+						Description:  "The required token type.",
+						Default:      "password",
+					},
+					{
+						Name:         "User",
+						OriginalName: "user",
+						Type:         "string",
+						Description:  "The optional user (name or ID) used for non-token based credentials.",
+					},
+				},
+			},
+			{
+				Name:        "Root",
+				FQDTN:       "tosca.datatypes.Root",
+				Description: "The TOSCA root Data Type all other TOSCA base Data Types derive from",
+				Fields:      []model.Field{},
+			},
+		}, false},
+		{"TestParseExcludeFilters", &Parser{
+			ExcludePatterns: []string{`tosca\.datatypes\.Cred.*`, `tosca\.datatypes.TimeInterval`},
+			NameMappings:    map[string]string{`tosca\.datatypes.TimeInterval`: "something.else.but.excluded.anyway"},
+		}, args{"testdata/normative-light.yaml"}, []model.DataType{
+			{
+				Name:        "Root",
+				FQDTN:       "tosca.datatypes.Root",
+				Description: "The TOSCA root Data Type all other TOSCA base Data Types derive from",
+				Fields:      []model.Field{},
+			},
+		}, false},
+		{"ImportNotFound", &Parser{}, args{"testdata/imports/root.yaml"}, nil, true},
+		{"MissingImport", &Parser{}, args{"testdata/imports/missing.yaml"}, nil, true},
+		{"CircularImports", &Parser{}, args{"testdata/imports/circular-a.yaml"}, nil, true},
+		{"DuplicateImportedDataType", &Parser{}, args{"testdata/imports/duplicate.yaml"}, nil, true},
+		{"TestParseImports", &Parser{ImportPaths: []string{"testdata/imports/lib"}}, args{"testdata/imports/root.yaml"}, []model.DataType{
+			{
+				Name:        "Derived",
+				FQDTN:       "org.ystia.datatypes.Derived",
+				DerivedFrom: "Base",
+				Fields: []model.Field{
+					{
+						Name:           "Ext",
+						OriginalName:   "ext",
+						Type:           "Ext",
+						Required:       true,
+						UnderlyingType: "int",
+					},
+					{
+						Name:           "Shared",
+						OriginalName:   "shared",
+						Type:           "Shared",
+						Required:       true,
+						UnderlyingType: "string",
+					},
+				},
+			},
+		}, false},
+		{"TestParseIncludeImportedTypes", &Parser{ImportPaths: []string{"testdata/imports/lib"}, IncludeImportedTypes: true, ExcludePatterns: []string{`Derived`}}, args{"testdata/imports/root.yaml"}, []model.DataType{
+			{
+				Name:  "Base",
+				FQDTN: "org.ystia.datatypes.Base",
+				Fields: []model.Field{
+					{
+						Name:         "Name",
+						OriginalName: "name",
+						Type:         "string",
+						Required:     true,
+					},
+				},
+			},
+			{
+				Name:           "Ext",
+				FQDTN:          "org.ystia.datatypes.Ext",
+				DerivedFrom:    "int",
+				Fields:         []model.Field{},
+				UnderlyingType: "int",
+			},
+			{
+				Name:           "Shared",
+				FQDTN:          "org.ystia.datatypes.Shared",
+				DerivedFrom:    "string",
+				Fields:         []model.Field{},
+				UnderlyingType: "string",
+			},
+		}, false},
+		{"InvalidCSAR", &Parser{}, args{"testdata/csar/donotexists.csar"}, nil, true},
+		{"CSARWithoutEntryDefinitions", &Parser{}, args{"testdata/csar/missing-entry.csar"}, nil, true},
+		{"CSARWithoutMetaAndSeveralDefinitions", &Parser{}, args{"testdata/csar/nometa-ambiguous.csar"}, nil, true},
+		{"CSARWithMissingImport", &Parser{}, args{"testdata/csar/missing-import.csar"}, nil, true},
+		{"TestParseCSAR", &Parser{}, args{"testdata/csar/valid.csar"}, []model.DataType{
+			{
+				Name:        "Endpoint",
+				FQDTN:       "org.ystia.datatypes.Endpoint",
+				DerivedFrom: "Base",
+				Fields: []model.Field{
+					{
+						Name:         "Port",
+						OriginalName: "port",
+						Type:         "int",
+						Required:     true,
+					},
+				},
+			},
+		}, false},
+		{"TestParseCSARWithoutMeta", &Parser{}, args{"testdata/csar/nometa.zip"}, []model.DataType{
+			{
+				Name:  "Base",
+				FQDTN: "org.ystia.datatypes.Base",
+				Fields: []model.Field{
+					{
+						Name:         "Name",
+						OriginalName: "name",
+						Type:         "string",
+						Required:     true,
+					},
+				},
+			},
+		}, false},
+		{"InvalidConstraintOperator", &Parser{}, args{"testdata/invalid-constraint-operator.yaml"}, nil, true},
+		{"InvalidConstraintValues", &Parser{}, args{"testdata/invalid-constraint-values.yaml"}, nil, true},
+		{"SchemaConstraintOperator", &Parser{}, args{"testdata/schema-constraint-operator.yaml"}, nil, true},
+		{"TestParseTypedConstraints", &Parser{}, args{"../../testdata/typed-constraints.yaml"}, []model.DataType{
+			{
+				Name:           "APIVersion",
+				FQDTN:          "org.ystia.datatypes.APIVersion",
+				DerivedFrom:    "Version",
+				Fields:         []model.Field{},
+				UnderlyingType: "Version",
+				Constraints: []model.Constraint{
+					{Operator: "greater_or_equal", Values: []interface{}{"1.10"}},
+				},
+			},
+			{
+				Name:           "Expiration",
+				FQDTN:          "org.ystia.datatypes.Expiration",
+				DerivedFrom:    "time.Time",
+				Fields:         []model.Field{},
+				UnderlyingType: "time.Time",
+				Constraints: []model.Constraint{
+					{Operator: "in_range", Values: []interface{}{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)}},
+				},
+			},
+			{
+				Name:           "Ports",
+				FQDTN:          "org.ystia.datatypes.Ports",
+				DerivedFrom:    "Range",
+				Fields:         []model.Field{},
+				UnderlyingType: "Range",
+				Constraints: []model.Constraint{
+					{Operator: "in_range", Values: []interface{}{1, 65535}},
+				},
+			},
+			{
+				Name:  "Server",
+				FQDTN: "org.ystia.datatypes.Server",
+				Fields: []model.Field{
+					{Name: "Aliases", OriginalName: "aliases", Type: "[]string", EntrySchema: &model.EntrySchema{
+						Constraints: []model.Constraint{
+							{Operator: "pattern", Values: []interface{}{"[a-z]+"}},
+							{Operator: "max_length", Values: []interface{}{16}},
+						},
+					}},
+					{Name: "APIVersion", OriginalName: "api_version", Type: "APIVersion", UnderlyingType: "Version"},
+					{Name: "Created", OriginalName: "created", Type: "time.Time", Constraints: []model.Constraint{
+						{Operator: "greater_or_equal", Values: []interface{}{"2001-12-14 21:59:43.10 -5"}},
+					}},
+					{Name: "Expiration", OriginalName: "expiration", Type: "Expiration", UnderlyingType: "time.Time"},
+					{Name: "Limits", OriginalName: "limits", Type: "map[string][]ScalarUnitSize", EntrySchema: &model.EntrySchema{
+						EntrySchema: &model.EntrySchema{
+							Constraints: []model.Constraint{
+								{Operator: "greater_than", Values: []interface{}{"0 B"}},
+							},
+						},
+					}},
+					{Name: "Memory", OriginalName: "memory", Type: "ScalarUnitSize", Constraints: []model.Constraint{
+						{Operator: "in_range", Values: []interface{}{"512 MiB", "64 GiB"}},
+					}},
+					{Name: "MinVersion", OriginalName: "min_version", Type: "Version", Constraints: []model.Constraint{
+						{Operator: "valid_values", Values: []interface{}{"1.10", "2.0"}},
+					}},
+					{Name: "Name", OriginalName: "name", Type: "string", Required: true, Constraints: []model.Constraint{
+						{Operator: "pattern", Values: []interface{}{"[a-z]+(-[a-z]+)*"}},
+					}},
+					{Name: "Ports", OriginalName: "ports", Type: "Ports", UnderlyingType: "Range"},
+					{Name: "Timeout", OriginalName: "timeout", Type: "ScalarUnitTime", Required: true, Constraints: []model.Constraint{
+						{Operator: "less_or_equal", Values: []interface{}{"1 h"}},
+					}},
+					{Name: "Weight", OriginalName: "weight", Type: "Weight", UnderlyingType: "int"},
+				},
+			},
+			{
+				Name:           "Weight",
+				FQDTN:          "org.ystia.datatypes.Weight",
+				DerivedFrom:    "int",
+				Fields:         []model.Field{},
+				UnderlyingType: "int",
+				Constraints: []model.Constraint{
+					{Operator: "greater_than", Values: []interface{}{0}},
+				},
+			},
+		}, false},
+		{"TestParseConstraints", &Parser{}, args{"testdata/constraints.yaml"}, []model.DataType{
+			{
+				Name:           "PortDef",
+				FQDTN:          "org.ystia.datatypes.PortDef",
+				DerivedFrom:    "int",
+				Fields:         []model.Field{},
+				UnderlyingType: "int",
+				Constraints: []model.Constraint{
+					{Operator: "in_range", Values: []interface{}{1, "UNBOUNDED"}},
+				},
+			},
+			{
+				Name:  "PortSpec",
+				FQDTN: "org.ystia.datatypes.PortSpec",
+				Fields: []model.Field{
+					{
+						Name:         "Protocol",
+						OriginalName: "protocol",
+						Type:         "string",
+						Required:     true,
+						Constraints: []model.Constraint{
+							{Operator: "valid_values", Values: []interface{}{"udp", "tcp", "igmp"}},
+							{Operator: "pattern", Values: []interface{}{"[a-z]+"}},
+						},
+					},
+					{
+						Name:           "Target",
+						OriginalName:   "target",
+						Type:           "UserPortDef",
+						Required:       true,
+						UnderlyingType: "int",
+						Constraints: []model.Constraint{
+							{Operator: "less_than", Values: []interface{}{60000}},
+						},
+					},
+				},
+			},
+			{
+				Name:           "UserPortDef",
+				FQDTN:          "org.ystia.datatypes.UserPortDef",
+				DerivedFrom:    "PortDef",
+				Fields:         []model.Field{},
+				UnderlyingType: "int",
+				Constraints: []model.Constraint{
+					{Operator: "greater_or_equal", Values: []interface{}{1024}},
+				},
+			},
+		}, false},
+		{"TestParseNodeTypes", &Parser{ParseNodeTypes: true, ExcludePatterns: []string{"Credential"}}, args{"../../testdata/node-types.yaml"}, []model.DataType{
+			{
+				Name:        "RootAttributes",
+				FQDTN:       "tosca.nodes.Root",
+				Kind:        model.NodeTypeAttributesKind,
+				Description: "The TOSCA Node Type all other TOSCA base Node Types derive from",
+				Fields: []model.Field{
+					{Name: "State", OriginalName: "state", Type: "string"},
+					{Name: "ToscaID", OriginalName: "tosca_id", Type: "string"},
+				},
+			},
+			{
+				Name:        "RootProperties",
+				FQDTN:       "tosca.nodes.Root",
+				Kind:        model.NodeTypePropertiesKind,
+				Description: "The TOSCA Node Type all other TOSCA base Node Types derive from",
+				Fields:      []model.Field{},
+			},
+			{
+				Name:        "SoftwareComponentAttributes",
+				FQDTN:       "tosca.nodes.SoftwareComponent",
+				Kind:        model.NodeTypeAttributesKind,
+				DerivedFrom: "RootAttributes",
+				Fields: []model.Field{
+					{Name: "Endpoints", OriginalName: "endpoints", Type: "[]string", Default: []interface{}{}},
+				},
+			},
+			{
+				Name:        "SoftwareComponentProperties",
+				FQDTN:       "tosca.nodes.SoftwareComponent",
+				Kind:        model.NodeTypePropertiesKind,
+				DerivedFrom: "RootProperties",
+				Fields: []model.Field{
+					{Name: "AdminCredential", OriginalName: "admin_credential", Type: "Credential"},
+					{Name: "ComponentVersion", OriginalName: "component_version", Type: "Version"},
+				},
+			},
+		}, false},
+		{"TestParseCapabilityAndRelationshipTypes", &Parser{
+			ParseNodeTypes:         true,
+			ParseCapabilityTypes:   true,
+			ParseRelationshipTypes: true,
+			IncludePatterns:        []string{`^tosca\.capabilities\.`, `^tosca\.relationships\.ConnectsTo$`},
+		}, args{"../../testdata/node-types.yaml"}, []model.DataType{
+			{
+				Name:        "EndpointCapabilityProperties",
+				FQDTN:       "tosca.capabilities.Endpoint",
+				Kind:        model.CapabilityTypePropertiesKind,
+				DerivedFrom: "RootCapabilityProperties",
+				Fields: []model.Field{
+					{Name: "Port", OriginalName: "port", Type: "int"},
+					{Name: "Protocol", OriginalName: "protocol", Type: "string", Required: true, Default: "tcp"},
+				},
+			},
+			{
+				Name:        "RootCapabilityProperties",
+				FQDTN:       "tosca.capabilities.Root",
+				Kind:        model.CapabilityTypePropertiesKind,
+				Description: "The TOSCA root Capability Type all other TOSCA base Capability Types derive from",
+				Fields:      []model.Field{},
+			},
+			{
+				Name:        "ConnectsToRelationshipProperties",
+				FQDTN:       "tosca.relationships.ConnectsTo",
+				Kind:        model.RelationshipTypePropertiesKind,
+				DerivedFrom: "RootRelationshipProperties",
+				Fields: []model.Field{
+					{Name: "Credential", OriginalName: "credential", Type: "Credential"},
+				},
+			},
+		}, false},
+		{"TestParseOperationInputs", &Parser{
+			ParseOperationInputs: true,
+			ExcludePatterns:      []string{`^org\.ystia\.datatypes\.`},
+		}, args{"../../testdata/interfaces.yaml"}, []model.DataType{
+			{
+				Name:        "MaintenanceConfigureInputs",
+				FQDTN:       "org.ystia.interfaces.Maintenance",
+				Kind:        model.InterfaceTypeOperationInputsKind,
+				Operation:   "configure",
+				DerivedFrom: "StandardConfigureInputs",
+				Description: "Configure the node in maintenance mode.",
+				Fields: []model.Field{
+					{Name: "DryRun", OriginalName: "dry_run", Type: "bool"},
+					{Name: "Reason", OriginalName: "reason", Type: "string", Required: true},
+					{Name: "Retries", OriginalName: "retries", Type: "int", Default: 1, Refinement: true},
+				},
+			},
+			{
+				Name:      "MaintenanceUpgradeInputs",
+				FQDTN:     "org.ystia.interfaces.Maintenance",
+				Kind:      model.InterfaceTypeOperationInputsKind,
+				Operation: "upgrade",
+				Fields: []model.Field{
+					{Name: "DryRun", OriginalName: "dry_run", Type: "bool"},
+					{Name: "Repositories", OriginalName: "repositories", Type: "[]Repository", Required: true},
+				},
+			},
+			{
+				Name:      "ApplicationStandardCreateInputs",
+				FQDTN:     "org.ystia.nodes.Application",
+				Kind:      model.NodeTypeOperationInputsKind,
+				Operation: "Standard.create",
+				Fields: []model.Field{
+					{Name: "InstallDir", OriginalName: "install_dir", Type: "string", Required: true, Default: "/opt/app"},
+				},
+			},
+			{
+				Name:        "WebApplicationStandardConfigureInputs",
+				FQDTN:       "org.ystia.nodes.WebApplication",
+				Kind:        model.NodeTypeOperationInputsKind,
+				Operation:   "Standard.configure",
+				DerivedFrom: "StandardConfigureInputs",
+				Fields: []model.Field{
+					{Name: "ContextRoot", OriginalName: "context_root", Type: "string", Required: true},
+				},
+			},
+			{
+				Name:        "WebApplicationStandardCreateInputs",
+				FQDTN:       "org.ystia.nodes.WebApplication",
+				Kind:        model.NodeTypeOperationInputsKind,
+				Operation:   "Standard.create",
+				DerivedFrom: "ApplicationStandardCreateInputs",
+				Fields: []model.Field{
+					{Name: "InstallDir", OriginalName: "install_dir", Type: "string", Required: true, Default: "/opt/app", Refinement: true, Constraints: []model.Constraint{
+						{Operator: "min_length", Values: []interface{}{1}},
+					}},
+					{Name: "Port", OriginalName: "port", Type: "int", Required: true},
+				},
+			},
+			{
+				Name:        "StandardConfigureInputs",
+				FQDTN:       "tosca.interfaces.node.lifecycle.Standard",
+				Kind:        model.InterfaceTypeOperationInputsKind,
+				Operation:   "configure",
+				Description: "Standard lifecycle configure operation.",
+				Fields: []model.Field{
+					{Name: "Retries", OriginalName: "retries", Type: "int", Default: 3},
+				},
+			},
+		}, false},
+		{"TestParseKeySchema", &Parser{}, args{"../../testdata/key-schema.yaml"}, []model.DataType{
+			{
+				Name:           "Port",
+				FQDTN:          "org.ystia.datatypes.Port",
+				DerivedFrom:    "int",
+				Fields:         []model.Field{},
+				UnderlyingType: "int",
+			},
+			{
+				Name:  "Releases",
+				FQDTN: "org.ystia.datatypes.Releases",
+				Fields: []model.Field{
+					{Name: "Codename", OriginalName: "codename", Type: "string", Default: "1.10"},
+					{Name: "Labels", OriginalName: "labels", Type: "map[string]string"},
+					{Name: "Latest", OriginalName: "latest", Type: "Version", Default: "1.10"},
+					{Name: "Notes", OriginalName: "notes", Type: "map[Version]string", Required: true, Default: map[string]interface{}{"1.10": "tenth release"}},
+					{Name: "Services", OriginalName: "services", Type: "map[Port]string"},
+					{Name: "Weights", OriginalName: "weights", Type: "map[int]float64", Default: map[interface{}]interface{}{1: 0.5, 2: 0.5}},
+				},
+			},
+		}, false},
+		{"InvalidKeySchema", &Parser{}, args{"testdata/invalid-key-schema.yaml"}, nil, true},
+		{"TestParseNestedCollections", &Parser{}, args{"../../testdata/nested-collections.yaml"}, []model.DataType{
+			{
+				Name:  "Endpoint",
+				FQDTN: "org.ystia.datatypes.Endpoint",
+				Fields: []model.Field{
+					{Name: "URL", OriginalName: "url", Type: "string", Required: true},
+				},
+			},
+			{
+				Name:  "Matrix",
+				FQDTN: "org.ystia.datatypes.Matrix",
+				Fields: []model.Field{
+					{Name: "Cells", OriginalName: "cells", Type: "[][]int", Required: true, Default: []interface{}{[]interface{}{1, 0}, []interface{}{0, 1}}},
+					{Name: "Endpoints", OriginalName: "endpoints", Type: "[]map[string]Endpoint"},
+					{Name: "Groups", OriginalName: "groups", Type: "map[string][]string", Default: map[string]interface{}{"admins": []interface{}{"root"}}},
+					{Name: "Releases", OriginalName: "releases", Type: "map[Version]map[int][]string"},
+				},
+			},
+		}, false},
+		{"InvalidNestedKeySchema", &Parser{}, args{"testdata/invalid-nested-key-schema.yaml"}, nil, true},
+		{"TestParseDisambiguateNames", &Parser{DisambiguateNames: true, ParseNodeTypes: true}, args{"../../testdata/collisions.yaml"}, []model.DataType{
+			{
+				Name:  "AConfig",
+				FQDTN: "org.ystia.a.Config",
+				Fields: []model.Field{
+					{Name: "Path", OriginalName: "path", Type: "string", Required: true},
+				},
+			},
+			{Name: "AServerAttributes", FQDTN: "org.ystia.a.Server", Kind: model.NodeTypeAttributesKind, Fields: []model.Field{}},
+			{
+				Name:  "AServerProperties",
+				FQDTN: "org.ystia.a.Server",
+				Kind:  model.NodeTypePropertiesKind,
+				Fields: []model.Field{
+					{Name: "Config", OriginalName: "config", Type: "AConfig", Required: true},
+				},
+			},
+			{
+				Name:  "BConfig",
+				FQDTN: "org.ystia.b.Config",
+				Fields: []model.Field{
+					{Name: "URL", OriginalName: "url", Type: "string", Required: true},
+				},
+			},
+			{Name: "BServerAttributes", FQDTN: "org.ystia.b.Server", Kind: model.NodeTypeAttributesKind, DerivedFrom: "AServerAttributes", Fields: []model.Field{}},
+			{
+				Name:        "BServerProperties",
+				FQDTN:       "org.ystia.b.Server",
+				Kind:        model.NodeTypePropertiesKind,
+				DerivedFrom: "AServerProperties",
+				Fields: []model.Field{
+					{Name: "Settings", OriginalName: "settings", Type: "Settings", Required: true},
+				},
+			},
+			{
+				Name:  "Settings",
+				FQDTN: "org.ystia.datatypes.Settings",
+				Fields: []model.Field{
+					{Name: "Local", OriginalName: "local", Type: "AConfig", Required: true},
+					{Name: "Remote", OriginalName: "remote", Type: "[]BConfig", Required: true},
+				},
+			},
+		}, false},
+		{"FieldNamesCollisions", &Parser{}, args{"../../testdata/field-collisions.yaml"}, nil, true},
+		{"TestParseSuffixCollidingFields", &Parser{SuffixCollidingFields: true}, args{"../../testdata/field-collisions.yaml"}, []model.DataType{
+			{
+				Name:  "Base",
+				FQDTN: "org.ystia.datatypes.Base",
+				Fields: []model.Field{
+					{Name: "MyField", OriginalName: "my-field", Type: "string", Required: true},
+					{Name: "MyField2", OriginalName: "my_field", Type: "string", Required: true},
+					{Name: "PortNumber", OriginalName: "port_number", Type: "int", Required: true},
+				},
+			},
+			{
+				Name:        "Derived",
+				FQDTN:       "org.ystia.datatypes.Derived",
+				DerivedFrom: "Base",
+				Fields: []model.Field{
+					{Name: "Base2", OriginalName: "base", Type: "string", Required: true},
+					{Name: "MyField3", OriginalName: "myField", Type: "string", Required: true},
+					{Name: "PortNumber2", OriginalName: "port-number", Type: "int", Required: true},
+					{Name: "PortNumber", OriginalName: "port_number", Type: "int", Description: "Refined port number", Required: true, Refinement: true},
+				},
+			},
+		}, false},
+		{"TestParseFlattenStructs", &Parser{FlattenStructs: true, ParseNodeTypes: true}, args{"../../testdata/flatten.yaml"}, []model.DataType{
+			{
+				Name:          "Endpoint",
+				FQDTN:         "org.ystia.datatypes.Endpoint",
+				FlattenedFrom: "Root",
+				Fields: []model.Field{
+					{Name: "Port", OriginalName: "port", Type: "int"},
+					{Name: "Protocol", OriginalName: "protocol", Type: "string", Required: true, Default: "tcp"},
+				},
+			},
+			{
+				Name:           "Port",
+				FQDTN:          "org.ystia.datatypes.Port",
+				DerivedFrom:    "int",
+				Fields:         []model.Field{},
+				UnderlyingType: "int",
+			},
+			{
+				Name:        "Root",
+				FQDTN:       "org.ystia.datatypes.Root",
+				Description: "The root data type",
+				Fields:      []model.Field{},
+			},
+			{
+				Name:          "SecureEndpoint",
+				FQDTN:         "org.ystia.datatypes.SecureEndpoint",
+				FlattenedFrom: "Endpoint",
+				Fields: []model.Field{
+					{Name: "Certificate", OriginalName: "certificate", Type: "string", Required: true},
+					{Name: "Port", OriginalName: "port", Type: "int", Inherited: true},
+					{Name: "Protocol", OriginalName: "protocol", Type: "string", Required: true, Default: "https", Inherited: true},
+				},
+			},
+			{Name: "RootAttributes", FQDTN: "org.ystia.nodes.Root", Kind: model.NodeTypeAttributesKind, Fields: []model.Field{}},
+			{
+				Name:  "RootProperties",
+				FQDTN: "org.ystia.nodes.Root",
+				Kind:  model.NodeTypePropertiesKind,
+				Fields: []model.Field{
+					{Name: "Name", OriginalName: "name", Type: "string", Required: true},
+				},
+			},
+			{Name: "ServerAttributes", FQDTN: "org.ystia.nodes.Server", Kind: model.NodeTypeAttributesKind, FlattenedFrom: "RootAttributes", Fields: []model.Field{}},
+			{
+				Name:          "ServerProperties",
+				FQDTN:         "org.ystia.nodes.Server",
+				Kind:          model.NodeTypePropertiesKind,
+				FlattenedFrom: "RootProperties",
+				Fields: []model.Field{
+					{Name: "Endpoint", OriginalName: "endpoint", Type: "SecureEndpoint", Required: true},
+					{Name: "Name", OriginalName: "name", Type: "string", Required: true, Inherited: true},
+				},
+			},
+		}, false},
+		{"TestParseRefinement", &Parser{}, args{"../../testdata/refinement.yaml"}, []model.DataType{
+			{
+				Name:  "Credential",
+				FQDTN: "org.ystia.datatypes.Credential",
+				Fields: []model.Field{
+					{Name: "User", OriginalName: "user", Type: "string", Required: true},
+				},
+			},
+			{
+				Name:  "Endpoint",
+				FQDTN: "org.ystia.datatypes.Endpoint",
+				Fields: []model.Field{
+					{Name: "Credential", OriginalName: "credential", Type: "Credential"},
+					{Name: "Port", OriginalName: "port", Type: "int", Constraints: []model.Constraint{{Operator: "greater_or_equal", Values: []interface{}{1}}}},
+					{Name: "Protocol", OriginalName: "protocol", Type: "string", Required: true, Default: "tcp"},
+				},
+			},
+			{
+				Name:        "LocalEndpoint",
+				FQDTN:       "org.ystia.datatypes.LocalEndpoint",
+				DerivedFrom: "Endpoint",
+				Fields: []model.Field{
+					{Name: "Port", OriginalName: "port", Type: "int", Constraints: []model.Constraint{{Operator: "less_than", Values: []interface{}{1024}}}, Refinement: true},
+					{Name: "Protocol", OriginalName: "protocol", Type: "string", Required: true, Default: "unix", Refinement: true},
+				},
+			},
+			{
+				// Refinements narrowing the credential type and making port required are not
+				// compatible with fields promoted from Endpoint, SecureEndpoint is flattened
+				Name:          "SecureEndpoint",
+				FQDTN:         "org.ystia.datatypes.SecureEndpoint",
+				FlattenedFrom: "Endpoint",
+				Fields: []model.Field{
+					{Name: "Credential", OriginalName: "credential", Type: "TokenCredential", Inherited: true},
+					{Name: "Port", OriginalName: "port", Type: "int", Required: true, Constraints: []model.Constraint{
+						{Operator: "greater_or_equal", Values: []interface{}{1}},
+						{Operator: "less_than", Values: []interface{}{65536}},
+					}, Inherited: true},
+					{Name: "Protocol", OriginalName: "protocol", Type: "string", Description: "Secure protocol", Required: true, Default: "https", Inherited: true},
+				},
+			},
+			{
+				Name:          "SecureLocalEndpoint",
+				FQDTN:         "org.ystia.datatypes.SecureLocalEndpoint",
+				FlattenedFrom: "LocalEndpoint",
+				Fields: []model.Field{
+					{Name: "Credential", OriginalName: "credential", Type: "TokenCredential", Inherited: true},
+					{Name: "Port", OriginalName: "port", Type: "int", Constraints: []model.Constraint{
+						{Operator: "greater_or_equal", Values: []interface{}{1}},
+						{Operator: "less_than", Values: []interface{}{1024}},
+					}, Inherited: true},
+					{Name: "Protocol", OriginalName: "protocol", Type: "string", Required: true, Default: "unix", Inherited: true},
+				},
+			},
+			{
+				Name:        "TokenCredential",
+				FQDTN:       "org.ystia.datatypes.TokenCredential",
+				DerivedFrom: "Credential",
+				Fields: []model.Field{
+					{Name: "Token", OriginalName: "token", Type: "string", Required: true},
+				},
+			},
+		}, false},
+		{"TestParseFlattenedRefinement", &Parser{FlattenStructs: true, IncludePatterns: []string{`SecureEndpoint$`}}, args{"../../testdata/refinement.yaml"}, []model.DataType{
+			{
+				Name:          "SecureEndpoint",
+				FQDTN:         "org.ystia.datatypes.SecureEndpoint",
+				FlattenedFrom: "Endpoint",
+				Fields: []model.Field{
+					{Name: "Credential", OriginalName: "credential", Type: "TokenCredential", Inherited: true},
+					{Name: "Port", OriginalName: "port", Type: "int", Required: true, Constraints: []model.Constraint{
+						{Operator: "greater_or_equal", Values: []interface{}{1}},
+						{Operator: "less_than", Values: []interface{}{65536}},
+					}, Inherited: true},
+					{Name: "Protocol", OriginalName: "protocol", Type: "string", Description: "Secure protocol", Required: true, Default: "https", Inherited: true},
+				},
+			},
+		}, false},
+		{"InvalidRefinementType", &Parser{}, args{"testdata/invalid-refinement-type.yaml"}, nil, true},
+		{"InvalidRefinementRequired", &Parser{}, args{"testdata/invalid-refinement-required.yaml"}, nil, true},
+		{"UndefinedParentFallback", &Parser{ParseNodeTypes: true, UnresolvedTypesFallback: "interface{}"}, args{"testdata/undefined-node-parent.yaml"}, []model.DataType{
+			{Name: "ServerAttributes", FQDTN: "org.ystia.nodes.Server", Kind: model.NodeTypeAttributesKind, Fields: []model.Field{}},
+			{
+				Name:  "ServerProperties",
+				FQDTN: "org.ystia.nodes.Server",
+				Kind:  model.NodeTypePropertiesKind,
+				Fields: []model.Field{
+					{Name: "Port", OriginalName: "port", Type: "int", Required: true},
+				},
+			},
+		}, false},
+		{"InvalidInputRefinement", &Parser{ParseOperationInputs: true}, args{"testdata/invalid-input-refinement.yaml"}, nil, true},
+		{"TestParseLenientTimestamps", &Parser{LenientTimestamps: true}, args{"testdata/timestamps.yaml"}, []model.DataType{
+			{
+				Name:           "Expiration",
+				FQDTN:          "org.ystia.datatypes.Expiration",
+				DerivedFrom:    "Timestamp",
+				Fields:         []model.Field{},
+				UnderlyingType: "Timestamp",
+			},
+			{
+				Name:  "Schedule",
+				FQDTN: "org.ystia.datatypes.Schedule",
+				Fields: []model.Field{
+					{
+						Name:         "Dates",
+						OriginalName: "dates",
+						Type:         "[]Timestamp",
+						Required:     true,
+					},
+					{
+						Name:           "Expiration",
+						OriginalName:   "expiration",
+						Type:           "Expiration",
+						Required:       true,
+						UnderlyingType: "Timestamp",
+					},
+					{
+						Name:         "Start",
+						OriginalName: "start",
+						Type:         "Timestamp",
+						Required:     true,
+					},
+				},
+			},
+		}, false},
+		{"TestParseOptionalPointers", &Parser{OptionalPointers: true}, args{"testdata/optional.yaml"}, []model.DataType{
+			{
+				Name:  "Optionals",
+				FQDTN: "org.ystia.datatypes.Optionals",
+				Fields: []model.Field{
+					{
+						Name:         "Nested",
+						OriginalName: "nested",
+						Type:         "Optionals",
+					},
+					{
+						Name:         "Port",
+						OriginalName: "port",
+						Type:         "*int",
+					},
+					{
+						Name:           "PortDef",
+						OriginalName:   "port_def",
+						Type:           "*PortDef",
+						UnderlyingType: "int",
+					},
+					{
+						Name:         "RequiredPort",
+						OriginalName: "required_port",
+						Type:         "int",
+						Required:     true,
+					},
+					{
+						Name:         "Tags",
+						OriginalName: "tags",
+						Type:         "[]string",
+					},
+				},
+			},
+			{
+				Name:           "PortDef",
+				FQDTN:          "org.ystia.datatypes.PortDef",
+				DerivedFrom:    "int",
+				Fields:         []model.Field{},
+				UnderlyingType: "int",
+			},
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			got, err := tt.p.ParseTypes(tt.args.filePath)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parser.ParseTypes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err == nil {
+				assert.DeepEqual(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParser_ParseTypesUnresolvedTypes(t *testing.T) {
+	p := &Parser{ParseNodeTypes: true}
+	_, err := p.ParseTypes("../../testdata/unresolved.yaml")
+	var unresolvedErr *UnresolvedTypesError
+	assert.Assert(t, errors.As(err, &unresolvedErr))
+	assert.DeepEqual(t, unresolvedErr.References, []UnresolvedTypeReference{
+		{Type: "org.acme.types.User", Property: "owner", ReferencedBy: "org.ystia.datatypes.Config"},
+		{Type: "org.acme.types.Tag", Property: "tags", ReferencedBy: "org.ystia.datatypes.Config"},
+		{Type: "org.acme.types.Backend", Property: "backends", ReferencedBy: "org.ystia.nodes.Service"},
+	})
+}
+
+func TestCheckNameCollisions(t *testing.T) {
+	p := &Parser{ParseNodeTypes: true}
+	dataTypes, err := p.ParseTypes("../../testdata/collisions.yaml")
+	assert.NilError(t, err)
+	err = CheckNameCollisions(dataTypes)
+	var collisionErr *NameCollisionError
+	assert.Assert(t, errors.As(err, &collisionErr))
+	assert.DeepEqual(t, collisionErr.Collisions, map[string][]string{
+		"Config":           {"org.ystia.a.Config", "org.ystia.b.Config"},
+		"ServerAttributes": {"org.ystia.a.Server", "org.ystia.b.Server"},
+		"ServerProperties": {"org.ystia.a.Server", "org.ystia.b.Server"},
+	})
+
+	p.DisambiguateNames = true
+	dataTypes, err = p.ParseTypes("../../testdata/collisions.yaml")
+	assert.NilError(t, err)
+	assert.NilError(t, CheckNameCollisions(dataTypes))
+}
+
+func TestParser_ParseTypesInvalidDerivations(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     DerivationError
+	}{
+		{"CyclicDerivation", "../../testdata/cyclic-derivation.yaml", DerivationError{
+			Kind:   "data type",
+			Chain:  []string{"org.ystia.datatypes.A", "org.ystia.datatypes.B", "org.ystia.datatypes.C", "org.ystia.datatypes.A"},
+			Cyclic: true,
+		}},
+		{"DerivationFromNodeType", "testdata/invalid-derivation.yaml", DerivationError{
+			Kind:       "data type",
+			Chain:      []string{"org.ystia.datatypes.Base", "tosca.nodes.Root"},
+			ParentKind: "node type",
+		}},
+		{"UndefinedParent", "testdata/undefined-parent.yaml", DerivationError{
+			Kind:      "data type",
+			Chain:     []string{"org.ystia.datatypes.Base", "org.ystia.datatypes.Missing"},
+			Undefined: true,
+		}},
+		{"UndefinedNodeTypeParent", "testdata/undefined-node-parent.yaml", DerivationError{
+			Kind:      "node type",
+			Chain:     []string{"org.ystia.nodes.Server", "tosca.nodes.Root"},
+			Undefined: true,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Parser{}
+			_, err := p.ParseTypes(tt.filePath)
+			var derivationErr *DerivationError
+			assert.Assert(t, errors.As(err, &derivationErr), "unexpected error %v", err)
+			assert.DeepEqual(t, *derivationErr, tt.want)
+		})
+	}
+}
+
+func TestParser_ParseTypesFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"defs/types.yaml": {Data: []byte(`tosca_definitions_version: tosca_simple_yaml_1_2
+imports:
+  - common/base.yaml
+data_types:
+  org.ystia.datatypes.Child:
+    derived_from: org.ystia.datatypes.Base
+    properties:
+      name:
+        type: string
+`)},
+		"defs/common/base.yaml": {Data: []byte(`tosca_definitions_version: tosca_simple_yaml_1_2
+data_types:
+  org.ystia.datatypes.Base:
+    properties:
+      id:
+        type: integer
+`)},
+	}
+	p := &Parser{IncludeImportedTypes: true}
+	got, err := p.ParseTypesFS(context.Background(), fsys, "defs/types.yaml")
+	assert.NilError(t, err)
+	assert.Equal(t, len(got), 2)
+	assert.Equal(t, got[0].Name, "Base")
+	assert.Equal(t, got[1].DerivedFrom, "Base")
+
+	// Imports of definitions read from a reader are resolved relatively to the file system root
+	b, err := fs.ReadFile(fsys, "defs/types.yaml")
+	assert.NilError(t, err)
+	_, err = p.ParseTypesReader(context.Background(), bytes.NewReader(b), fsys)
+	assert.ErrorContains(t, err, "not found")
+	got, err = p.ParseTypesReader(context.Background(), bytes.NewReader(b), fstest.MapFS{"common/base.yaml": fsys["defs/common/base.yaml"]})
+	assert.NilError(t, err)
+	assert.Equal(t, len(got), 2)
+}
+
+func TestParser_ParseTypesSameImportThroughImportPaths(t *testing.T) {
+	// shared.yaml is imported relatively by the root file and through an absolute import path by lib/uses-shared.yaml
+	importPath, err := filepath.Abs("testdata/imports")
+	assert.NilError(t, err)
+	p := &Parser{ImportPaths: []string{importPath}, IncludeImportedTypes: true}
+	got, err := p.ParseTypes("testdata/imports/root-import-paths.yaml")
+	assert.NilError(t, err)
+	names := make([]string, 0, len(got))
+	for _, dt := range got {
+		names = append(names, dt.FQDTN)
+	}
+	assert.DeepEqual(t, names, []string{"org.ystia.datatypes.Root", "org.ystia.datatypes.Shared", "org.ystia.datatypes.UsesShared"})
+}
@@ -0,0 +1,146 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ystia/tdt2go/pkg/parser/internal/tosca"
+)
+
+// inheritedProperties returns the properties of derivedFrom and of its parents, properties redefined
+// by a type are refined following TOSCA 1.3 property refinement rules, see refineProperty
+func (p *Parser) inheritedProperties(derivedFrom string, lookup propertiesLookup, dataTypes map[string]dataTypeDefinition) (map[string]tosca.PropertyDefinition, error) {
+	chain := make([]string, 0)
+	visited := make(map[string]bool)
+	for derivedFrom != "" && !visited[derivedFrom] {
+		visited[derivedFrom] = true
+		parent, _, ok := lookup(derivedFrom)
+		if !ok {
+			break
+		}
+		chain = append(chain, derivedFrom)
+		derivedFrom = parent
+	}
+	inherited := make(map[string]tosca.PropertyDefinition)
+	for i := len(chain) - 1; i >= 0; i-- {
+		_, props, _ := lookup(chain[i])
+		for pName, prop := range props {
+			if parentProp, ok := inherited[pName]; ok {
+				var err error
+				prop, err = p.refineProperty(chain[i], pName, parentProp, prop, dataTypes)
+				if err != nil {
+					return nil, err
+				}
+			}
+			inherited[pName] = prop
+		}
+	}
+	return inherited, nil
+}
+
+// refineProperty returns the refinement of a parent property by a property of the same name
+// defined by typeName.
+//
+// Following TOSCA 1.3 rules, the type of the refined property should be the same or derived from the parent
+// one, a required property could not be made optional, constraints are added to the parent ones and other
+// keynames override the parent ones when they are defined.
+func (p *Parser) refineProperty(typeName, pName string, parent, prop tosca.PropertyDefinition, dataTypes map[string]dataTypeDefinition) (tosca.PropertyDefinition, error) {
+	refined := parent
+	if prop.Type != "" {
+		if !isCompatibleType(prop.Type, &prop.EntrySchema, &prop.KeySchema, parent.Type, &parent.EntrySchema, &parent.KeySchema, dataTypes) {
+			return refined, fmt.Errorf("invalid refinement of property %q by %q: type %s is not compatible with inherited type %s", pName, typeName, schemaString(prop.Type, &prop.EntrySchema), schemaString(parent.Type, &parent.EntrySchema))
+		}
+		refined.Type = prop.Type
+		if prop.EntrySchema.Type != "" {
+			refined.EntrySchema = prop.EntrySchema
+			refined.EntrySchema.Constraints = append(append([]tosca.ConstraintClause{}, parent.EntrySchema.Constraints...), prop.EntrySchema.Constraints...)
+		}
+		if prop.KeySchema.Type != "" {
+			refined.KeySchema = prop.KeySchema
+		}
+	}
+	if prop.Required != nil {
+		if !*prop.Required && (parent.Required == nil || *parent.Required) {
+			return refined, fmt.Errorf("invalid refinement of property %q by %q: a required property could not be made optional", pName, typeName)
+		}
+		refined.Required = prop.Required
+	}
+	if prop.Description != "" {
+		refined.Description = prop.Description
+	}
+	if prop.Default != nil {
+		refined.Default = prop.Default
+	}
+	if prop.Status != "" {
+		refined.Status = prop.Status
+	}
+	refined.Constraints = append(append([]tosca.ConstraintClause{}, parent.Constraints...), prop.Constraints...)
+	return refined, nil
+}
+
+// isCompatibleType returns true if a TOSCA type is the same or derives from a parent type, entry and key
+// schemas of collections should also be compatible
+func isCompatibleType(toscaType string, entrySchema, keySchema *tosca.EntrySchema, parentType string, parentEntrySchema, parentKeySchema *tosca.EntrySchema, dataTypes map[string]dataTypeDefinition) bool {
+	if !isDerivedType(toscaType, parentType, dataTypes) {
+		return false
+	}
+	switch strings.ToLower(toscaType) {
+	case "list", "map":
+		return isCompatibleSchema(entrySchema, parentEntrySchema, dataTypes) && isCompatibleSchema(keySchema, parentKeySchema, dataTypes)
+	}
+	return true
+}
+
+// isCompatibleSchema returns true if an entry or key schema refines a parent one, undefined
+// schemas are compatible with any parent schema
+func isCompatibleSchema(schema, parent *tosca.EntrySchema, dataTypes map[string]dataTypeDefinition) bool {
+	if schema == nil || parent == nil || schema.Type == "" || parent.Type == "" {
+		return true
+	}
+	return isCompatibleType(schema.Type, schema.EntrySchema, schema.KeySchema, parent.Type, parent.EntrySchema, parent.KeySchema, dataTypes)
+}
+
+// isDerivedType returns true if a TOSCA type is the same or derives, directly or through its parents,
+// from another type
+func isDerivedType(toscaType, parentType string, dataTypes map[string]dataTypeDefinition) bool {
+	visited := make(map[string]bool)
+	for toscaType != "" && !visited[toscaType] {
+		if toscaType == parentType {
+			return true
+		}
+		visited[toscaType] = true
+		toscaType = dataTypes[toscaType].DerivedFrom
+	}
+	return false
+}
+
+func schemaString(toscaType string, entrySchema *tosca.EntrySchema) string {
+	if entrySchema != nil && entrySchema.Type != "" {
+		return fmt.Sprintf("%q of %s", toscaType, schemaString(entrySchema.Type, entrySchema.EntrySchema))
+	}
+	return fmt.Sprintf("%q", toscaType)
+}
+
+// schemaWithoutConstraints returns a copy of an entry schema and of its nested entry schemas without their constraints
+func schemaWithoutConstraints(s tosca.EntrySchema) tosca.EntrySchema {
+	s.Constraints = nil
+	if s.EntrySchema != nil {
+		nested := schemaWithoutConstraints(*s.EntrySchema)
+		s.EntrySchema = &nested
+	}
+	return s
+}
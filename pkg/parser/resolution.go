@@ -0,0 +1,97 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ystia/tdt2go/pkg/parser/internal/tosca"
+)
+
+// UnresolvedTypeReference is a reference from a property to a TOSCA type which is neither defined in
+// the TOSCA definition file nor in its imports
+type UnresolvedTypeReference struct {
+	// Type is the fully qualified name of the unknown TOSCA type
+	Type string
+	// Property is the name of the property referencing the unknown type
+	Property string
+	// ReferencedBy is the fully qualified name of the TOSCA type defining the property
+	ReferencedBy string
+}
+
+// UnresolvedTypesError is returned when properties reference unknown TOSCA types, it lists
+// every unresolved references
+type UnresolvedTypesError struct {
+	References []UnresolvedTypeReference
+}
+
+func (e *UnresolvedTypesError) Error() string {
+	refs := make([]string, 0, len(e.References))
+	for _, r := range e.References {
+		refs = append(refs, fmt.Sprintf("type %q referenced by property %q of %q", r.Type, r.Property, r.ReferencedBy))
+	}
+	return "unresolved TOSCA types: " + strings.Join(refs, ", ")
+}
+
+// unresolvedTypesError returns an UnresolvedTypesError listing the given references in a stable order,
+// or nil if there is no unresolved references
+func unresolvedTypesError(refs []UnresolvedTypeReference) error {
+	if len(refs) == 0 {
+		return nil
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].ReferencedBy != refs[j].ReferencedBy {
+			return refs[i].ReferencedBy < refs[j].ReferencedBy
+		}
+		if refs[i].Property != refs[j].Property {
+			return refs[i].Property < refs[j].Property
+		}
+		return refs[i].Type < refs[j].Type
+	})
+	return &UnresolvedTypesError{References: refs}
+}
+
+// isKnownType returns true if a TOSCA type is a primitive or collection type or a known data type
+func isKnownType(toscaType string, dataTypes map[string]dataTypeDefinition) bool {
+	switch strings.ToLower(toscaType) {
+	case "list", "map":
+		return true
+	}
+	_, ok := dataTypes[toscaType]
+	return ok || isTOSCAPrimitiveType(toscaType)
+}
+
+// unresolvedTypes returns unknown TOSCA types referenced by a property either as its type or in its
+// entry and key schemas
+func unresolvedTypes(prop tosca.PropertyDefinition, dataTypes map[string]dataTypeDefinition) []string {
+	return appendUnresolvedTypes(nil, prop.Type, &prop.EntrySchema, &prop.KeySchema, dataTypes)
+}
+
+func appendUnresolvedTypes(types []string, toscaType string, entrySchema, keySchema *tosca.EntrySchema, dataTypes map[string]dataTypeDefinition) []string {
+	if toscaType != "" && !isKnownType(toscaType, dataTypes) {
+		types = append(types, toscaType)
+	}
+	switch strings.ToLower(toscaType) {
+	case "list", "map":
+		for _, s := range []*tosca.EntrySchema{keySchema, entrySchema} {
+			if s != nil {
+				types = appendUnresolvedTypes(types, s.Type, s.EntrySchema, s.KeySchema, dataTypes)
+			}
+		}
+	}
+	return types
+}
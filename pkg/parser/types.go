@@ -0,0 +1,147 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"strings"
+
+	"github.com/ystia/tdt2go/pkg/model"
+	"github.com/ystia/tdt2go/pkg/parser/internal/tosca"
+)
+
+// convertNodeTypes converts properties and attributes of node types into model.DataTypes named
+// <Type>Properties and <Type>Attributes, derived from their parent node type ones
+func (p *Parser) convertNodeTypes(defs *definitions) ([]model.DataType, error) {
+	result := make([]model.DataType, 0)
+	for ntName, nt := range defs.nodeTypes {
+		selected, err := p.isSelected(ntName, nt.definitionOrigin)
+		if err != nil {
+			return nil, err
+		}
+		if !selected {
+			continue
+		}
+		properties, err := p.convertTypeProperties(ntName, nt.Type, nt.Properties, model.NodeTypePropertiesKind, "Properties", defs)
+		if err != nil {
+			return nil, err
+		}
+		attributes, err := p.convertTypeProperties(ntName, nt.Type, attributesAsProperties(nt.Attributes), model.NodeTypeAttributesKind, "Attributes", defs)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, properties, attributes)
+	}
+	return result, nil
+}
+
+// convertCapabilityTypes converts properties of capability types into model.DataTypes named
+// <Type>CapabilityProperties, derived from their parent capability type ones
+func (p *Parser) convertCapabilityTypes(defs *definitions) ([]model.DataType, error) {
+	result := make([]model.DataType, 0)
+	for ctName, ct := range defs.capabilityTypes {
+		selected, err := p.isSelected(ctName, ct.definitionOrigin)
+		if err != nil {
+			return nil, err
+		}
+		if !selected {
+			continue
+		}
+		properties, err := p.convertTypeProperties(ctName, ct.Type, ct.Properties, model.CapabilityTypePropertiesKind, "CapabilityProperties", defs)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, properties)
+	}
+	return result, nil
+}
+
+// convertRelationshipTypes converts properties of relationship types into model.DataTypes named
+// <Type>RelationshipProperties, derived from their parent relationship type ones
+func (p *Parser) convertRelationshipTypes(defs *definitions) ([]model.DataType, error) {
+	result := make([]model.DataType, 0)
+	for rtName, rt := range defs.relationshipTypes {
+		selected, err := p.isSelected(rtName, rt.definitionOrigin)
+		if err != nil {
+			return nil, err
+		}
+		if !selected {
+			continue
+		}
+		properties, err := p.convertTypeProperties(rtName, rt.Type, rt.Properties, model.RelationshipTypePropertiesKind, "RelationshipProperties", defs)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, properties)
+	}
+	return result, nil
+}
+
+// isSelected returns true if a TOSCA type should be extracted according to include and exclude patterns
+// and to the IncludeImportedTypes option
+func (p *Parser) isSelected(name string, origin definitionOrigin) (bool, error) {
+	if origin.imported && !p.IncludeImportedTypes {
+		return false, nil
+	}
+	return p.nameValidatesPatterns(name)
+}
+
+// convertTypeProperties converts properties of a TOSCA type into a model.DataType named after the TOSCA type
+// with the given suffix
+func (p *Parser) convertTypeProperties(typeName string, t tosca.Type, props map[string]tosca.PropertyDefinition, kind model.TypeKind, suffix string, defs *definitions) (model.DataType, error) {
+	parent := p.derivedTypeName(t.DerivedFrom, suffix)
+	fields, flattened, err := p.convertTypeFields(typeName, t.DerivedFrom, parent, props, defs.propertiesLookup(kind), defs)
+	if err != nil {
+		return model.DataType{}, err
+	}
+	dt := model.DataType{
+		Name:        p.convertDTName(typeName) + suffix,
+		FQDTN:       typeName,
+		Kind:        kind,
+		DerivedFrom: parent,
+		Description: strings.Trim(t.Description, " \t\n"),
+		Fields:      fields,
+	}
+	if flattened {
+		dt.FlattenedFrom, dt.DerivedFrom = parent, ""
+	}
+	return dt, nil
+}
+
+// derivedTypeName returns the Go name of the type generated for the parent of a TOSCA type
+func (p *Parser) derivedTypeName(parent, suffix string) string {
+	if parent == "" {
+		return ""
+	}
+	return p.convertDTName(parent) + suffix
+}
+
+// attributesAsProperties converts attribute definitions into optional property definitions
+// so they could be converted into fields like properties
+func attributesAsProperties(attributes map[string]tosca.AttributeDefinition) map[string]tosca.PropertyDefinition {
+	required := false
+	props := make(map[string]tosca.PropertyDefinition, len(attributes))
+	for name, attr := range attributes {
+		props[name] = tosca.PropertyDefinition{
+			Type:        attr.Type,
+			Description: attr.Description,
+			Required:    &required,
+			Default:     attr.Default,
+			Status:      attr.Status,
+			EntrySchema: attr.EntrySchema,
+			KeySchema:   attr.KeySchema,
+		}
+	}
+	return props
+}
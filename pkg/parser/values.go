@@ -0,0 +1,132 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ystia/tdt2go/pkg/model"
+	"github.com/ystia/tdt2go/pkg/parser/internal/tosca"
+)
+
+// decodeValue decodes a TOSCA value, like a property default value or constraint values, according to its TOSCA type.
+//
+// Scalar values of string, version and scalar-unit types are kept as written in the TOSCA file
+// instead of letting YAML resolve them (a 1.10 version would otherwise be decoded as the 1.1 float),
+// collections and complex data types are decoded recursively according to their schemas and properties.
+func (p *Parser) decodeValue(node *yaml.Node, toscaType string, entrySchema, keySchema *tosca.EntrySchema, defs *definitions) (interface{}, error) {
+	if node == nil {
+		return nil, nil
+	}
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			return nil, nil
+		}
+		switch t := p.primitiveType(toscaType, defs.dataTypes); {
+		case t == "string", t == "version", strings.HasPrefix(t, "scalar-unit"):
+			return node.Value, nil
+		}
+	case yaml.SequenceNode:
+		if strings.ToLower(toscaType) != "list" {
+			break
+		}
+		values := make([]interface{}, 0, len(node.Content))
+		for _, n := range node.Content {
+			v, err := p.schemaValue(n, entrySchema, defs)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	case yaml.MappingNode:
+		if strings.ToLower(toscaType) == "map" {
+			keyType := "string"
+			if keySchema != nil && keySchema.Type != "" {
+				keyType = keySchema.Type
+			}
+			return p.mappingValue(node, keyType, func(_ interface{}, n *yaml.Node) (interface{}, error) {
+				return p.schemaValue(n, entrySchema, defs)
+			}, defs)
+		}
+		if _, ok := defs.dataTypes[toscaType]; !ok || p.primitiveType(toscaType, defs.dataTypes) != "" {
+			break
+		}
+		props, err := p.inheritedProperties(toscaType, defs.propertiesLookup(model.DataTypeKind), defs.dataTypes)
+		if err != nil {
+			return nil, err
+		}
+		return p.mappingValue(node, "string", func(key interface{}, n *yaml.Node) (interface{}, error) {
+			name, _ := key.(string)
+			prop := props[name]
+			return p.decodeValue(n, prop.Type, &prop.EntrySchema, &prop.KeySchema, defs)
+		}, defs)
+	}
+	var v interface{}
+	err := node.Decode(&v)
+	return v, err
+}
+
+// schemaValue decodes an entry of a collection value according to the collection entry schema
+func (p *Parser) schemaValue(node *yaml.Node, schema *tosca.EntrySchema, defs *definitions) (interface{}, error) {
+	if schema == nil {
+		return p.decodeValue(node, "", nil, nil, defs)
+	}
+	return p.decodeValue(node, schema.Type, schema.EntrySchema, schema.KeySchema, defs)
+}
+
+// mappingValue decodes a YAML mapping, keys are decoded according to keyType and values by entryValue.
+//
+// Like YAML does, the mapping is decoded as a map[string]interface{} if all keys are strings and as
+// a map[interface{}]interface{} otherwise.
+func (p *Parser) mappingValue(node *yaml.Node, keyType string, entryValue func(key interface{}, node *yaml.Node) (interface{}, error), defs *definitions) (interface{}, error) {
+	values := make(map[interface{}]interface{}, len(node.Content)/2)
+	stringKeys := true
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, err := p.decodeValue(node.Content[i], keyType, nil, nil, defs)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := key.(string); !ok {
+			stringKeys = false
+		}
+		values[key], err = entryValue(key, node.Content[i+1])
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !stringKeys {
+		return values, nil
+	}
+	m := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		m[k.(string)] = v
+	}
+	return m, nil
+}
+
+// valueNode returns the YAML node of a TOSCA value, nil if the value is not defined
+func valueNode(v *tosca.Value) *yaml.Node {
+	if v == nil {
+		return nil
+	}
+	return &v.Node
+}
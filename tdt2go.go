@@ -15,15 +15,19 @@
 package tdt2go
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
-	"github.com/ystia/tdt2go/internal/pkg/generator"
-	"github.com/ystia/tdt2go/internal/pkg/model"
-	"github.com/ystia/tdt2go/internal/pkg/parser"
+	"github.com/ystia/tdt2go/pkg/generator"
+	"github.com/ystia/tdt2go/pkg/model"
+	"github.com/ystia/tdt2go/pkg/parser"
 	"golang.org/x/tools/go/packages"
 )
 
@@ -38,13 +42,35 @@ type Options struct {
 	includePatterns      []string
 	excludePatterns      []string
 	nameMappings         map[string]string
+	importPaths          []string
+	generateImported     bool
+	generateValidation   bool
+	generateEnums        bool
+	optionalPointers     bool
+	generateDefaults     bool
+	lenientTimestamps    bool
+	generateNodeTypes    bool
+	generateCapabilities bool
+	generateRelations    bool
+	generateOperations   bool
+	unresolvedFallback   string
+	disambiguateNames    bool
+	suffixFields         bool
+	flattenStructs       bool
+	parentMethods        bool
+	fsys                 fs.FS
+	outputDir            string
+	splitByNamespace     bool
+	removeStaleFiles     bool
 }
 
 // Option is a function that is allowed to tweak Options
 type Option func(*Options)
 
 // GenerateBuiltinTypes option control if TOSCA builtin types should be generated along with
-// other datatypes. This option is false by default.
+// other datatypes. Scalar-unit types are generated with methods parsing and comparing their values
+// and with unmarshalers checking them. Range is generated as a struct supporting UNBOUNDED upper bounds
+// and Version as a struct supporting parsing and comparison. This option is false by default.
 func GenerateBuiltinTypes(p bool) Option {
 	return func(o *Options) {
 		o.generateBuiltinTypes = p
@@ -97,6 +123,176 @@ func NameMappings(mappings map[string]string) Option {
 	}
 }
 
+// ImportPaths is a list of directories where TOSCA imports are searched for when they can't be found
+// relatively to the importing file.
+//
+// Defaults to no additional import paths.
+func ImportPaths(paths []string) Option {
+	return func(o *Options) {
+		o.importPaths = paths
+	}
+}
+
+// GenerateImportedTypes option control if datatypes defined in imported TOSCA files should be generated
+// along with datatypes of the given TOSCA file. This option is false by default.
+func GenerateImportedTypes(p bool) Option {
+	return func(o *Options) {
+		o.generateImported = p
+	}
+}
+
+// GenerateValidation option control if a Validate method enforcing TOSCA constraints should be generated
+// on each datatype. This option is false by default.
+func GenerateValidation(p bool) Option {
+	return func(o *Options) {
+		o.generateValidation = p
+	}
+}
+
+// GenerateEnums option control if string properties restricted by a valid_values constraint should be
+// generated using a dedicated string type with a constant for each valid value. This option is false by default.
+func GenerateEnums(p bool) Option {
+	return func(o *Options) {
+		o.generateEnums = p
+	}
+}
+
+// OptionalPointers option control if optional properties of scalar types should be generated as pointers
+// so absent values could be distinguished from zero values. This option is false by default.
+func OptionalPointers(p bool) Option {
+	return func(o *Options) {
+		o.optionalPointers = p
+	}
+}
+
+// GenerateDefaults option control if a constructor and a SetDefaults method applying TOSCA default values
+// should be generated on each complex datatype. This option is false by default.
+func GenerateDefaults(p bool) Option {
+	return func(o *Options) {
+		o.generateDefaults = p
+	}
+}
+
+// LenientTimestamps option control if TOSCA timestamps should be generated using a generated Timestamp
+// type instead of time.Time. Timestamp decodes every YAML 1.1 timestamp forms (like "2001-12-14 21:59:43.10 -5")
+// used by TOSCA while time.Time only accepts RFC 3339 timestamps. This option is false by default.
+func LenientTimestamps(p bool) Option {
+	return func(o *Options) {
+		o.lenientTimestamps = p
+	}
+}
+
+// GenerateNodeTypes option control if properties and attributes of TOSCA node types should be generated as
+// <Type>Properties and <Type>Attributes structs along with datatypes. Include and exclude patterns also apply
+// to node types names. This option is false by default.
+func GenerateNodeTypes(p bool) Option {
+	return func(o *Options) {
+		o.generateNodeTypes = p
+	}
+}
+
+// GenerateCapabilityTypes option control if properties of TOSCA capability types should be generated as
+// <Type>CapabilityProperties structs along with datatypes. Include and exclude patterns also apply to capability
+// types names. This option is false by default.
+func GenerateCapabilityTypes(p bool) Option {
+	return func(o *Options) {
+		o.generateCapabilities = p
+	}
+}
+
+// GenerateRelationshipTypes option control if properties of TOSCA relationship types should be generated as
+// <Type>RelationshipProperties structs along with datatypes. Include and exclude patterns also apply to
+// relationship types names. This option is false by default.
+func GenerateRelationshipTypes(p bool) Option {
+	return func(o *Options) {
+		o.generateRelations = p
+	}
+}
+
+// GenerateOperationInputs option control if inputs of operations of TOSCA interface types should be generated as
+// <Interface><Operation>Inputs structs and inputs of operations defined in interfaces of node types as
+// <Type><Interface><Operation>Inputs structs along with datatypes. Include and exclude patterns also apply to
+// interface types and node types names. This option is false by default.
+func GenerateOperationInputs(p bool) Option {
+	return func(o *Options) {
+		o.generateOperations = p
+	}
+}
+
+// UnresolvedTypesFallback option defines the Go type (like interface{} or map[string]interface{}) used for
+// properties referencing TOSCA types that are neither defined in the TOSCA file nor in its imports.
+// By default generation fails listing every unresolved type references.
+func UnresolvedTypesFallback(goType string) Option {
+	return func(o *Options) {
+		o.unresolvedFallback = goType
+	}
+}
+
+// DisambiguateNames option control if TOSCA types whose Go names collide (like org.a.Config and org.b.Config
+// which are both named Config) should be prefixed with their distinguishing namespace segments (like AConfig and
+// BConfig). Otherwise generation fails on names collisions. This option is false by default.
+func DisambiguateNames(p bool) Option {
+	return func(o *Options) {
+		o.disambiguateNames = p
+	}
+}
+
+// SuffixCollidingFields option control if Go fields names colliding with other fields names of a same type
+// (like my-field and my_field which are both named MyField) or with inherited fields names should be suffixed
+// by a number (like MyField2). Otherwise generation fails on fields names collisions. This option is false
+// by default.
+func SuffixCollidingFields(p bool) Option {
+	return func(o *Options) {
+		o.suffixFields = p
+	}
+}
+
+// FlattenStructs option control if inherited properties should be copied into structs generated for derived
+// types instead of embedding the parent struct. Operations inputs structs still embed their parent struct.
+// This option is false by default.
+func FlattenStructs(p bool) Option {
+	return func(o *Options) {
+		o.flattenStructs = p
+	}
+}
+
+// GenerateParentMethods option control if a Parent method converting values into their parent type should be
+// generated on flattened structs, see FlattenStructs. This option is false by default.
+func GenerateParentMethods(p bool) Option {
+	return func(o *Options) {
+		o.parentMethods = p
+	}
+}
+
+// OutputDirectory is a directory where generated code is written as one Go source file per generated type
+// (like port_spec.go for a PortSpec type) instead of being written on Output. Builtin types are generated into
+// a shared builtin.go file. Existing files are only overwritten if they were previously generated by tdt2go.
+//
+// It is used by GenerateFile and GenerateFiles, the directory is created if it doesn't exist.
+func OutputDirectory(dir string) Option {
+	return func(o *Options) {
+		o.outputDir = dir
+	}
+}
+
+// SplitByNamespace option control if types generated into the OutputDirectory should be grouped into one file
+// per TOSCA namespace (like org_ystia_datatypes.go for org.ystia.datatypes.PortSpec) instead of one file per type.
+// This option is false by default.
+func SplitByNamespace(p bool) Option {
+	return func(o *Options) {
+		o.splitByNamespace = p
+	}
+}
+
+// RemoveStaleFiles option control if Go source files previously generated by tdt2go into the OutputDirectory
+// should be removed when they are not generated anymore. Other files are never removed.
+// This option is false by default.
+func RemoveStaleFiles(p bool) Option {
+	return func(o *Options) {
+		o.removeStaleFiles = p
+	}
+}
+
 // OutputToFile is an helper function that allow to dump generated code into a file
 //
 // See Output
@@ -108,45 +304,157 @@ func OutputToFile(outputFile string, perm os.FileMode) (Option, error) {
 	return Output(f), nil
 }
 
-func defaultOptions(toscaFile string) (*Options, error) {
-	p, err := getCurrentPackage()
-	if err != nil {
-		return nil, err
+// FileSystem is the file system TOSCA imports of definitions given to Generate or GenerateBytes are resolved
+// from, relatively to its root directory then into import paths. It could be an embedded or a virtual file system.
+// Defaults to the operating system file system, relatively to the current working directory.
+func FileSystem(fsys fs.FS) Option {
+	return func(o *Options) {
+		o.fsys = fsys
 	}
+}
+
+func newOptions(ctx context.Context, opts []Option) (*Options, error) {
 	o := &Options{
-		pkg:    p,
 		output: os.Stdout,
 	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.pkg == "" {
+		p, err := getCurrentPackage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		o.pkg = p
+	}
 	return o, nil
 }
 
-// GenerateFile generates go code for TOSCA datatypes contains in the given TOSCA definition file.
+// Parse parses TOSCA datatypes contains in the given TOSCA definition file and returns the model.File
+// representation of the Go source file that GenerateFile would generate.
 //
-// Generation could be parametrized using Options.
-func GenerateFile(toscaFile string, opts ...Option) error {
-	options, err := defaultOptions(toscaFile)
+// It allows to reuse the parsed types graph in other tools. The TOSCA definition file could also be a CSAR
+// archive, see GenerateFile. Options related to parsing (like patterns, enums, builtin types or package name)
+// are taken into account, options related to code generation and output are ignored.
+func Parse(toscaFile string, opts ...Option) (model.File, error) {
+	return ParseFiles([]string{toscaFile}, opts...)
+}
+
+// ParseFiles is like Parse but parses several TOSCA definition files or CSAR archives, see GenerateFiles.
+func ParseFiles(toscaFiles []string, opts ...Option) (model.File, error) {
+	options, err := newOptions(context.Background(), opts)
 	if err != nil {
-		return err
+		return model.File{}, err
+	}
+	files, err := expandPatterns(toscaFiles, filepath.Glob)
+	if err != nil {
+		return model.File{}, err
 	}
-	for _, o := range opts {
-		o(options)
+	return parse(options, func(p *parser.Parser) ([]model.DataType, error) {
+		return p.ParseTypesFiles(files...)
+	})
+}
+
+func parse(options *Options, parseTypes func(p *parser.Parser) ([]model.DataType, error)) (model.File, error) {
+	p := &parser.Parser{
+		IncludePatterns:         options.includePatterns,
+		ExcludePatterns:         options.excludePatterns,
+		NameMappings:            options.nameMappings,
+		ImportPaths:             options.importPaths,
+		IncludeImportedTypes:    options.generateImported,
+		OptionalPointers:        options.optionalPointers,
+		LenientTimestamps:       options.lenientTimestamps,
+		ParseNodeTypes:          options.generateNodeTypes,
+		ParseCapabilityTypes:    options.generateCapabilities,
+		ParseRelationshipTypes:  options.generateRelations,
+		ParseOperationInputs:    options.generateOperations,
+		UnresolvedTypesFallback: options.unresolvedFallback,
+		DisambiguateNames:       options.disambiguateNames,
+		SuffixCollidingFields:   options.suffixFields,
+		FlattenStructs:          options.flattenStructs,
 	}
-	p := &parser.Parser{IncludePatterns: options.includePatterns, ExcludePatterns: options.excludePatterns, NameMappings: options.nameMappings}
-	dataTypes, err := p.ParseTypes(toscaFile)
+	dataTypes, err := parseTypes(p)
 	if err != nil {
-		return err
+		return model.File{}, err
 	}
 	if options.generateBuiltinTypes {
 		dataTypes = append(dataTypes, getBuiltinTypes()...)
 	}
-	f := model.File{
+	if options.lenientTimestamps {
+		dataTypes = append(dataTypes, getTimestampType())
+	}
+	var enums []model.Enum
+	if options.generateEnums {
+		// Builtin types are known so enums constants don't collide with them
+		dataTypes, enums = parser.ExtractEnums(dataTypes)
+	}
+	err = parser.CheckNameCollisions(dataTypes)
+	if err != nil {
+		return model.File{}, err
+	}
+	return model.File{
 		Package:   options.pkg,
 		Imports:   getImports(dataTypes),
 		DataTypes: dataTypes,
+		Enums:     enums,
+	}, nil
+}
+
+func newGenerator(options *Options) *generator.Generator {
+	return &generator.Generator{
+		GenerateValidation:    options.generateValidation,
+		GenerateDefaults:      options.generateDefaults,
+		GenerateParentMethods: options.parentMethods,
+	}
+}
+
+func generate(options *Options, parseTypes func(p *parser.Parser) ([]model.DataType, error)) ([]byte, error) {
+	f, err := parse(options, parseTypes)
+	if err != nil {
+		return nil, err
 	}
+	return newGenerator(options).GenerateFile(f)
+}
+
+// GenerateFile generates go code for TOSCA datatypes contains in the given TOSCA definition file.
+//
+// The TOSCA definition file could also be a CSAR archive (with a .csar or .zip extension), in this case
+// datatypes are extracted from the archive entry definitions file.
+//
+// Generation could be parametrized using Options.
+func GenerateFile(toscaFile string, opts ...Option) error {
+	return GenerateFiles([]string{toscaFile}, opts...)
+}
 
-	g := &generator.Generator{}
-	content, err := g.GenerateFile(f)
+// GenerateFiles generates go code for TOSCA datatypes contains in several TOSCA definition files or
+// CSAR archives into a single Go source file.
+//
+// toscaFiles could also be glob patterns (see filepath.Glob), a pattern matching no file is reported as an error.
+// Types of all files are merged into a single graph, so types of a file could reference types of another
+// file and builtin types are generated once. Files imported by several TOSCA files are loaded once but a
+// type defined in several files is reported as an error.
+//
+// Generation could be parametrized using Options.
+func GenerateFiles(toscaFiles []string, opts ...Option) error {
+	options, err := newOptions(context.Background(), opts)
+	if err != nil {
+		return err
+	}
+	files, err := expandPatterns(toscaFiles, filepath.Glob)
+	if err != nil {
+		return err
+	}
+	parseTypes := func(p *parser.Parser) ([]model.DataType, error) {
+		return p.ParseTypesFiles(files...)
+	}
+	if options.outputDir != "" {
+		f, err := parse(options, parseTypes)
+		if err != nil {
+			return err
+		}
+		return outputDirectory(f, options)
+	}
+	content, err := generate(options, parseTypes)
 	if err != nil {
 		return err
 	}
@@ -157,6 +465,83 @@ func GenerateFile(toscaFile string, opts ...Option) error {
 	return nil
 }
 
+// Generate returns go code generated for TOSCA datatypes contains in the TOSCA definition read from r.
+//
+// The TOSCA definition could be either a YAML TOSCA definition or a CSAR archive. Imports of a YAML
+// definition are resolved from the FileSystem option, imports of a CSAR archive are resolved inside it.
+//
+// Generation could be parametrized using Options, the Output and OutputDirectory options are ignored.
+// The context is checked before loading each TOSCA definition file and is used to look for the current
+// package name if the Package option is not set.
+func Generate(ctx context.Context, r io.Reader, opts ...Option) ([]byte, error) {
+	options, err := newOptions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return generate(options, func(p *parser.Parser) ([]model.DataType, error) {
+		return p.ParseTypesReader(ctx, r, options.fsys)
+	})
+}
+
+// GenerateBytes is like Generate but reads the TOSCA definition from the given content.
+func GenerateBytes(ctx context.Context, content []byte, opts ...Option) ([]byte, error) {
+	return Generate(ctx, bytes.NewReader(content), opts...)
+}
+
+// GenerateFS is like Generate but reads the TOSCA definition file toscaFile and its imports from fsys,
+// toscaFile is a slash-separated path within fsys. It could also be a CSAR archive, see GenerateFile.
+//
+// The FileSystem, Output and OutputDirectory options are ignored.
+func GenerateFS(ctx context.Context, fsys fs.FS, toscaFile string, opts ...Option) ([]byte, error) {
+	return GenerateFSFiles(ctx, fsys, []string{toscaFile}, opts...)
+}
+
+// GenerateFSFiles is like GenerateFS but reads several TOSCA definition files from fsys. Like for GenerateFiles,
+// they could be CSAR archives or glob patterns (see fs.Glob) and their types are merged into a single graph.
+func GenerateFSFiles(ctx context.Context, fsys fs.FS, toscaFiles []string, opts ...Option) ([]byte, error) {
+	options, err := newOptions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	files, err := expandPatterns(toscaFiles, func(pattern string) ([]string, error) {
+		return fs.Glob(fsys, pattern)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return generate(options, func(p *parser.Parser) ([]model.DataType, error) {
+		return p.ParseTypesFSFiles(ctx, fsys, files...)
+	})
+}
+
+// expandPatterns expands glob patterns of TOSCA files using glob. Other files are kept as is so missing
+// files are reported when they are parsed. Files matched by several patterns are kept once.
+func expandPatterns(patterns []string, glob func(pattern string) ([]string, error)) ([]string, error) {
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("no TOSCA file to generate")
+	}
+	files := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		matches := []string{pattern}
+		if strings.ContainsAny(pattern, "*?[") {
+			var err error
+			matches, err = glob(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid TOSCA files pattern %q: %w", pattern, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no TOSCA file matches pattern %q", pattern)
+			}
+		}
+		for _, m := range matches {
+			if !strSliceContains(files, m) {
+				files = append(files, m)
+			}
+		}
+	}
+	return files, nil
+}
+
 func outputFile(content []byte, options *Options) error {
 	_, err := options.output.Write(content)
 	if err != nil {
@@ -168,8 +553,12 @@ func outputFile(content []byte, options *Options) error {
 func getImports(dataTypes []model.DataType) []string {
 	imports := make(sort.StringSlice, 0)
 	for _, dt := range dataTypes {
+		types := []string{dt.DerivedFrom}
 		for _, f := range dt.Fields {
-			i := getImportForType(f.Type)
+			types = append(types, f.Type)
+		}
+		for _, t := range types {
+			i := getImportForType(t)
 			if i != "" && !strSliceContains(imports, i) {
 				imports = append(imports, i)
 			}
@@ -197,10 +586,11 @@ func strSliceContains(s []string, elem string) bool {
 	return false
 }
 
-func getCurrentPackage() (string, error) {
+func getCurrentPackage(ctx context.Context) (string, error) {
 	cfg := &packages.Config{
-		Mode:  packages.NeedName,
-		Tests: false,
+		Context: ctx,
+		Mode:    packages.NeedName,
+		Tests:   false,
 	}
 	pkgs, err := packages.Load(cfg, ".")
 	if err != nil {
@@ -215,39 +605,63 @@ func getCurrentPackage() (string, error) {
 func getBuiltinTypes() []model.DataType {
 	return []model.DataType{
 		{
-			Name:        "Range",
-			FQDTN:       "tosca:range",
-			DerivedFrom: "[]uint64",
+			Name:  "Range",
+			FQDTN: "tosca:range",
+			Fields: []model.Field{
+				{Name: "LowerBound", Type: "uint64", Description: "LowerBound is the lower bound of the range"},
+				{Name: "UpperBound", Type: "uint64", Description: "UpperBound is the upper bound of the range, it is ignored if the range is unbounded"},
+				{Name: "Unbounded", Type: "bool", Description: "Unbounded is true if the range has no upper bound (UNBOUNDED TOSCA keyword)"},
+			},
 		},
 		{
-			Name:        "ScalarUnit",
-			FQDTN:       "tosca:scalar-unit",
-			DerivedFrom: "string",
+			Name:           "ScalarUnit",
+			FQDTN:          "tosca:scalar-unit",
+			DerivedFrom:    "string",
+			UnderlyingType: "string",
 		},
 		{
-			Name:        "ScalarUnitBitRate",
-			FQDTN:       "tosca:scalar-unit.bitrate",
-			DerivedFrom: "ScalarUnit",
+			Name:           "ScalarUnitBitRate",
+			FQDTN:          "tosca:scalar-unit.bitrate",
+			DerivedFrom:    "ScalarUnit",
+			UnderlyingType: "string",
 		},
 		{
-			Name:        "ScalarUnitFrequency",
-			FQDTN:       "tosca:scalar-unit.frequency",
-			DerivedFrom: "ScalarUnit",
+			Name:           "ScalarUnitFrequency",
+			FQDTN:          "tosca:scalar-unit.frequency",
+			DerivedFrom:    "ScalarUnit",
+			UnderlyingType: "string",
 		},
 		{
-			Name:        "ScalarUnitSize",
-			FQDTN:       "tosca:scalar-unit.size",
-			DerivedFrom: "ScalarUnit",
+			Name:           "ScalarUnitSize",
+			FQDTN:          "tosca:scalar-unit.size",
+			DerivedFrom:    "ScalarUnit",
+			UnderlyingType: "string",
 		},
 		{
-			Name:        "ScalarUnitTime",
-			FQDTN:       "tosca:scalar-unit.time",
-			DerivedFrom: "ScalarUnit",
+			Name:           "ScalarUnitTime",
+			FQDTN:          "tosca:scalar-unit.time",
+			DerivedFrom:    "ScalarUnit",
+			UnderlyingType: "string",
 		},
 		{
-			Name:        "Version",
-			FQDTN:       "tosca:version",
-			DerivedFrom: "string",
+			Name:  "Version",
+			FQDTN: "tosca:version",
+			Fields: []model.Field{
+				{Name: "Major", Type: "uint64", Description: "Major is the major version number"},
+				{Name: "Minor", Type: "uint64", Description: "Minor is the minor version number"},
+				{Name: "Fix", Type: "uint64", Description: "Fix is the fix version number"},
+				{Name: "Qualifier", Type: "string", Description: "Qualifier is the optional version qualifier (like alpha or beta)"},
+				{Name: "Build", Type: "uint64", Description: "Build is the optional build version number of a qualified version"},
+			},
 		},
 	}
 }
+
+func getTimestampType() model.DataType {
+	return model.DataType{
+		Name:           "Timestamp",
+		FQDTN:          "tosca:timestamp",
+		DerivedFrom:    "time.Time",
+		UnderlyingType: "time.Time",
+	}
+}
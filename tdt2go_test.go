@@ -15,10 +15,15 @@
 package tdt2go
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"testing/fstest"
 
 	"gotest.tools/v3/assert"
 	"gotest.tools/v3/golden"
@@ -54,6 +59,35 @@ func TestGenerateFile(t *testing.T) {
 			NameMappings(map[string]string{`tosca\.datatypes\.(.+)`: `TOSCA_${1}`}),
 		}}, false},
 		{"NormativeLightPlusBuiltin", args{toscaFile: "testdata/normative-light.yaml", opts: []Option{GenerateBuiltinTypes(true)}}, false},
+		{"WithImports", args{toscaFile: "testdata/with-imports.yaml"}, false},
+		{"WithImportedTypes", args{toscaFile: "testdata/with-imports.yaml", opts: []Option{GenerateImportedTypes(true)}}, false},
+		{"MissingImport", args{toscaFile: "testdata/imports/with-import-paths.yaml"}, true},
+		{"CSAR", args{toscaFile: "testdata/normative-light.csar", opts: []Option{GenerateImportedTypes(true)}}, false},
+		{"Validation", args{toscaFile: "testdata/constraints.yaml", opts: []Option{GenerateValidation(true)}}, false},
+		{"TypedConstraints", args{toscaFile: "testdata/typed-constraints.yaml", opts: []Option{GenerateValidation(true), GenerateBuiltinTypes(true)}}, false},
+		{"TypedConstraintsLenientTimestamps", args{toscaFile: "testdata/typed-constraints.yaml", opts: []Option{GenerateValidation(true), GenerateBuiltinTypes(true), LenientTimestamps(true)}}, false},
+		{"Enums", args{toscaFile: "testdata/constraints.yaml", opts: []Option{GenerateEnums(true), GenerateValidation(true)}}, false},
+		{"OptionalPointers", args{toscaFile: "testdata/constraints.yaml", opts: []Option{OptionalPointers(true), GenerateEnums(true), GenerateValidation(true)}}, false},
+		{"Defaults", args{toscaFile: "testdata/constraints.yaml", opts: []Option{GenerateDefaults(true), GenerateEnums(true), GenerateBuiltinTypes(true)}}, false},
+		{"NodeTypes", args{toscaFile: "testdata/node-types.yaml", opts: []Option{GenerateNodeTypes(true), GenerateDefaults(true), GenerateBuiltinTypes(true)}}, false},
+		{"CapabilityAndRelationshipTypes", args{toscaFile: "testdata/node-types.yaml", opts: []Option{GenerateCapabilityTypes(true), GenerateRelationshipTypes(true), ExcludePatterns([]string{`^tosca\.nodes\.`})}}, false},
+		{"OperationInputs", args{toscaFile: "testdata/interfaces.yaml", opts: []Option{GenerateOperationInputs(true), GenerateDefaults(true)}}, false},
+		{"KeySchema", args{toscaFile: "testdata/key-schema.yaml", opts: []Option{GenerateDefaults(true), GenerateValidation(true), GenerateBuiltinTypes(true)}}, false},
+		{"NestedCollections", args{toscaFile: "testdata/nested-collections.yaml", opts: []Option{GenerateDefaults(true), GenerateValidation(true)}}, false},
+		{"UnresolvedTypes", args{toscaFile: "testdata/unresolved.yaml", opts: []Option{GenerateNodeTypes(true)}}, true},
+		{"UnresolvedTypesFallback", args{toscaFile: "testdata/unresolved.yaml", opts: []Option{GenerateNodeTypes(true), UnresolvedTypesFallback("interface{}")}}, false},
+		{"NameCollisions", args{toscaFile: "testdata/collisions.yaml", opts: []Option{GenerateNodeTypes(true)}}, true},
+		{"DisambiguateNames", args{toscaFile: "testdata/collisions.yaml", opts: []Option{GenerateNodeTypes(true), DisambiguateNames(true)}}, false},
+		{"FieldNamesCollisions", args{toscaFile: "testdata/field-collisions.yaml"}, true},
+		{"SuffixCollidingFields", args{toscaFile: "testdata/field-collisions.yaml", opts: []Option{SuffixCollidingFields(true)}}, false},
+		{"CyclicDerivation", args{toscaFile: "testdata/cyclic-derivation.yaml"}, true},
+		{"FlattenStructs", args{toscaFile: "testdata/flatten.yaml", opts: []Option{FlattenStructs(true), GenerateParentMethods(true), GenerateNodeTypes(true), GenerateDefaults(true), GenerateValidation(true)}}, false},
+		{"Refinement", args{toscaFile: "testdata/refinement.yaml", opts: []Option{GenerateDefaults(true), GenerateValidation(true)}}, false},
+		{"RefinementParentMethods", args{toscaFile: "testdata/refinement.yaml", opts: []Option{GenerateParentMethods(true), GenerateDefaults(true), GenerateValidation(true)}}, false},
+		{"FlattenedRefinement", args{toscaFile: "testdata/refinement.yaml", opts: []Option{FlattenStructs(true), GenerateParentMethods(true), GenerateDefaults(true), GenerateValidation(true)}}, false},
+		{"LenientTimestamps", args{toscaFile: "testdata/timestamps.yaml", opts: []Option{LenientTimestamps(true), GenerateDefaults(true), OptionalPointers(true)}}, false},
+		{"Timestamps", args{toscaFile: "testdata/timestamps.yaml", opts: []Option{GenerateDefaults(true)}}, false},
+		{"WithImportPaths", args{toscaFile: "testdata/imports/with-import-paths.yaml", opts: []Option{ImportPaths([]string{"testdata"})}}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -71,6 +105,111 @@ func TestGenerateFile(t *testing.T) {
 	}
 }
 
+func TestGenerateFiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		toscaFiles []string
+		golden     string
+		wantErr    bool
+	}{
+		{"MultipleFiles", []string{"testdata/multi/service.yaml", "testdata/multi/endpoint.yaml"}, "MultipleFiles", false},
+		{"MultipleFilesPatterns", []string{"testdata/multi/*.yaml", "testdata/multi/endpoint.yaml"}, "MultipleFiles", false},
+		{"NoFiles", nil, "", true},
+		{"NoMatchingPattern", []string{"testdata/multi/*.yml"}, "", true},
+		{"InvalidPattern", []string{"testdata/multi/[.yaml"}, "", true},
+		{"DuplicateTypes", []string{"testdata/multi/endpoint.yaml", "testdata/multi-conflict/endpoint.yaml"}, "", true},
+		{"DuplicateTypesInCSAR", []string{"testdata/multi/endpoint.yaml", "testdata/normative-light.csar"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &strings.Builder{}
+			err := GenerateFiles(tt.toscaFiles, Output(b), GenerateBuiltinTypes(true))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateFiles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil {
+				assert.Assert(t, golden.String(b.String(), "golden/"+tt.golden))
+			}
+		})
+	}
+}
+
+func TestOutputDirectory(t *testing.T) {
+	generatedFiles := func(dir string) []string {
+		entries, err := os.ReadDir(dir)
+		assert.NilError(t, err)
+		names := make([]string, 0, len(entries))
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		return names
+	}
+	writeFile := func(dir, name, content string) {
+		assert.NilError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0664))
+	}
+	toscaFiles := []string{"testdata/multi/*.yaml"}
+	opts := []Option{Package("multi"), GenerateBuiltinTypes(true), GenerateImportedTypes(true)}
+
+	t.Run("ByType", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "multi")
+		err := GenerateFiles(toscaFiles, append(opts, OutputDirectory(dir))...)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, generatedFiles(dir), []string{"builtin.go", "credential.go", "endpoint.go", "root.go", "service.go", "time_interval.go"})
+		for _, name := range []string{"endpoint.go", "service.go"} {
+			b, err := os.ReadFile(filepath.Join(dir, name))
+			assert.NilError(t, err)
+			assert.Assert(t, golden.String(string(b), "golden/OutputDirectory_"+strings.TrimSuffix(name, ".go")))
+		}
+	})
+	t.Run("ByNamespace", func(t *testing.T) {
+		dir := t.TempDir()
+		err := GenerateFiles(toscaFiles, append(opts, OutputDirectory(dir), SplitByNamespace(true))...)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, generatedFiles(dir), []string{"builtin.go", "org_ystia_datatypes_multi.go", "tosca_datatypes.go"})
+	})
+	t.Run("RemoveStaleFiles", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(dir, "stale.go", "// Code generated by tdt2go\n// DO NOT EDIT! ANY CHANGES MAY BE OVERWRITTEN.\n\npackage multi\n")
+		writeFile(dir, "custom.go", "package multi\n")
+		err := GenerateFiles(toscaFiles, append(opts, OutputDirectory(dir), SplitByNamespace(true), RemoveStaleFiles(true))...)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, generatedFiles(dir), []string{"builtin.go", "custom.go", "org_ystia_datatypes_multi.go", "tosca_datatypes.go"})
+	})
+	t.Run("KeepStaleFiles", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(dir, "stale.go", "// Code generated by tdt2go\n// DO NOT EDIT! ANY CHANGES MAY BE OVERWRITTEN.\n\npackage multi\n")
+		err := GenerateFiles(toscaFiles, append(opts, OutputDirectory(dir), SplitByNamespace(true))...)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, generatedFiles(dir), []string{"builtin.go", "org_ystia_datatypes_multi.go", "stale.go", "tosca_datatypes.go"})
+	})
+	t.Run("DoNotOverwriteOtherFiles", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(dir, "service.go", "package multi\n")
+		err := GenerateFiles(toscaFiles, append(opts, OutputDirectory(dir))...)
+		assert.ErrorContains(t, err, "was not generated by tdt2go")
+	})
+}
+
+func TestGoFileName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"port_spec", "port_spec.go"},
+		{"org.ystia.datatypes", "org_ystia_datatypes.go"},
+		{"windows", "windows.go"},
+		{"config_windows", "config_windows_types.go"},
+		{"config_arm64", "config_arm64_types.go"},
+		{"config_test", "config_test_types.go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, goFileName(tt.name), tt.want)
+		})
+	}
+}
+
 func TestOutputToFile(t *testing.T) {
 	type args struct {
 		outputFile string
@@ -103,3 +242,110 @@ func TestOutputToFile(t *testing.T) {
 		})
 	}
 }
+
+func TestParse(t *testing.T) {
+	f, err := Parse("testdata/constraints.yaml", Package("mypkg"), GenerateEnums(true), GenerateBuiltinTypes(true))
+	assert.NilError(t, err)
+	assert.Equal(t, f.Package, "mypkg")
+	assert.Assert(t, len(f.Enums) > 0, "enums should be extracted")
+
+	fqdtns := make(map[string]bool)
+	for _, dt := range f.DataTypes {
+		fqdtns[dt.FQDTN] = true
+	}
+	assert.Assert(t, fqdtns["tosca:range"], "builtin types should be included")
+
+	_, err = Parse("testdata/cyclic-derivation.yaml")
+	assert.ErrorContains(t, err, "cyclic")
+}
+
+func TestGenerate(t *testing.T) {
+	readFile := func(name string) []byte {
+		b, err := os.ReadFile(name)
+		assert.NilError(t, err)
+		return b
+	}
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		golden   string
+		generate func(opts ...Option) ([]byte, error)
+		opts     []Option
+		wantErr  bool
+	}{
+		{"Bytes", "NormativeLight", func(opts ...Option) ([]byte, error) {
+			return GenerateBytes(ctx, readFile("testdata/normative-light.yaml"), opts...)
+		}, nil, false},
+		{"ReaderWithImports", "WithImports", func(opts ...Option) ([]byte, error) {
+			f, err := os.Open("testdata/with-imports.yaml")
+			assert.NilError(t, err)
+			defer f.Close()
+			return Generate(ctx, f, opts...)
+		}, []Option{FileSystem(os.DirFS("testdata"))}, false},
+		{"ReaderWithUnresolvedImports", "", func(opts ...Option) ([]byte, error) {
+			return GenerateBytes(ctx, readFile("testdata/with-imports.yaml"), opts...)
+		}, []Option{FileSystem(fstest.MapFS{})}, true},
+		{"ReaderCSAR", "CSAR", func(opts ...Option) ([]byte, error) {
+			return GenerateBytes(ctx, readFile("testdata/normative-light.csar"), opts...)
+		}, []Option{GenerateImportedTypes(true)}, false},
+		{"FSWithImports", "WithImports", func(opts ...Option) ([]byte, error) {
+			return GenerateFS(ctx, os.DirFS("testdata"), "with-imports.yaml", opts...)
+		}, nil, false},
+		{"FSCSAR", "CSAR", func(opts ...Option) ([]byte, error) {
+			return GenerateFS(ctx, os.DirFS("testdata"), "normative-light.csar", opts...)
+		}, []Option{GenerateImportedTypes(true)}, false},
+		{"FSFilesPatterns", "MultipleFiles", func(opts ...Option) ([]byte, error) {
+			return GenerateFSFiles(ctx, os.DirFS("testdata"), []string{"multi/*.yaml"}, opts...)
+		}, []Option{GenerateBuiltinTypes(true)}, false},
+		{"CanceledContext", "", func(opts ...Option) ([]byte, error) {
+			canceled, cancel := context.WithCancel(ctx)
+			cancel()
+			return GenerateFS(canceled, os.DirFS("testdata"), "with-imports.yaml", opts...)
+		}, []Option{Package("tdt2go")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.generate(tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Generate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil {
+				assert.Assert(t, golden.String(string(got), "golden/"+tt.golden))
+			}
+		})
+	}
+}
+
+func TestGoldenDecoding(t *testing.T) {
+	if testing.Short() {
+		t.Skip("building generated code is skipped in short mode")
+	}
+	tests := []struct {
+		name   string
+		golden string
+		test   string
+	}{
+		{"TypedConstraints", "TypedConstraints", "typed_constraints_test.go"},
+		{"TypedConstraintsLenientTimestamps", "TypedConstraintsLenientTimestamps", "typed_constraints_test.go"},
+		{"LenientTimestamps", "LenientTimestamps", "lenient_timestamps_test.go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			copyFile := func(src, dst string) {
+				b, err := os.ReadFile(src)
+				assert.NilError(t, err)
+				assert.NilError(t, os.WriteFile(filepath.Join(dir, dst), b, 0664))
+			}
+			assert.NilError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module decoding\n\ngo 1.16\n"), 0664))
+			copyFile(filepath.Join("testdata", "golden", tt.golden), "generated.go")
+			copyFile(filepath.Join("testdata", "decoding", tt.test), tt.test)
+
+			cmd := exec.Command(filepath.Join(runtime.GOROOT(), "bin", "go"), "test", ".")
+			cmd.Dir = dir
+			out, err := cmd.CombinedOutput()
+			assert.NilError(t, err, string(out))
+		})
+	}
+}
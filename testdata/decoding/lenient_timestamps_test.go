@@ -0,0 +1,49 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tdt2go
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDerivedTimestampDecoding(t *testing.T) {
+	want := time.Date(2001, 12, 14, 21, 59, 43, 100000000, time.FixedZone("", -5*3600))
+	var s Schedule
+	err := json.Unmarshal([]byte(`{"start":"2001-12-14 21:59:43.10 -5","expiration":"2001-12-14 21:59:43.10 -5"}`), &s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Expiration == nil || !time.Time(*s.Expiration).Equal(want) {
+		t.Fatalf("unexpected expiration %v", s.Expiration)
+	}
+	b, err := json.Marshal(s.Expiration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `"2001-12-14T21:59:43.1-05:00"` {
+		t.Fatalf("unexpected JSON %s", b)
+	}
+
+	v, err := TimestampDecodeHook(reflect.TypeOf(""), reflect.TypeOf(Expiration{}), "2001-12-14 21:59:43.10 -5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !time.Time(v.(Expiration)).Equal(want) {
+		t.Fatalf("unexpected decoded expiration %v", v)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2018 Bull S.A.S. Atos Technologies - Bull, Rue Jean Jaures, B.P.68, 78340, Les Clayes-sous-Bois, France.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tdt2go
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDerivedVersionDecoding(t *testing.T) {
+	var s Server
+	err := json.Unmarshal([]byte(`{"api_version":"1.10"}`), &s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.APIVersion != (APIVersion{Major: 1, Minor: 10}) {
+		t.Fatalf("unexpected version %v", s.APIVersion)
+	}
+	if err = s.APIVersion.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(s.APIVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `"1.10.0"` {
+		t.Fatalf("unexpected JSON %s", b)
+	}
+	err = json.Unmarshal([]byte(`{"api_version":"1.a"}`), &s)
+	if err == nil {
+		t.Fatal("expecting an error for an invalid version")
+	}
+
+	v, err := BuiltinTypesDecodeHook(reflect.TypeOf(""), reflect.TypeOf(APIVersion{}), "1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != (APIVersion{Major: 1, Minor: 10}) {
+		t.Fatalf("unexpected decoded version %v", v)
+	}
+}
+
+func TestDerivedRangeDecoding(t *testing.T) {
+	var s Server
+	err := json.Unmarshal([]byte(`{"ports":[1, "UNBOUNDED"]}`), &s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Ports != (Ports{LowerBound: 1, Unbounded: true}) {
+		t.Fatalf("unexpected range %v", s.Ports)
+	}
+	if err = s.Ports.Validate(); err == nil {
+		t.Fatal("expecting an error for a range out of [1, 65535]")
+	}
+	err = json.Unmarshal([]byte(`{"ports":[80, 443]}`), &s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Ports.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := BuiltinTypesDecodeHook(reflect.TypeOf([]interface{}{}), reflect.TypeOf(Ports{}), []interface{}{1, "UNBOUNDED"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != (Ports{LowerBound: 1, Unbounded: true}) {
+		t.Fatalf("unexpected decoded range %v", v)
+	}
+}
+
+func TestTimestampConstraints(t *testing.T) {
+	if err := Expiration(time.Date(2019, 12, 31, 23, 59, 59, 0, time.UTC)).Validate(); err == nil {
+		t.Fatal("expecting an error for a timestamp before 2020-01-01")
+	}
+	if err := Expiration(time.Date(2025, 1, 1, 0, 0, 0, 0, time.FixedZone("", 3600))).Validate(); err != nil {
+		t.Fatal(err)
+	}
+}